@@ -1,5 +1,12 @@
 package meli
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
 type Attribute struct {
 	Id   string `json:"id,omitempty"`
 	Name string `json:"name,omitempty"`
@@ -10,11 +17,15 @@ type Attribute struct {
 
 	Tags []Tag `json:"tags,omitempty"`
 
-	Values         []*Value `json:"values,omitempty"`
-	ValueName      string   `json:"value_name,omitempty"`
-	ValueMaxLength int      `json:"value_max_length,omitempty"`
-	ValueType      string   `json:"value_type,omitempty"`
-	ValueId        string   `json:"value_id,omitempty"`
+	Values         []*Value    `json:"values,omitempty"`
+	ValueName      string      `json:"value_name,omitempty"`
+	ValueMaxLength int         `json:"value_max_length,omitempty"`
+	ValueType      string      `json:"value_type,omitempty"`
+	ValueId        string      `json:"value_id,omitempty"`
+	ValueStruct    *NumberUnit `json:"value_struct,omitempty"`
+
+	AllowedUnits []*Unit `json:"allowed_units,omitempty"`
+	DefaultUnit  string  `json:"default_unit,omitempty"`
 }
 
 // Value is the entity which represents the possible option values for the parent attr
@@ -24,6 +35,11 @@ type Value struct {
 	Struct interface{} `json:"struct,omitempty"`
 }
 
+type Unit struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
 type Tag map[string]bool
 
 func (attr *Attribute) tagValue(tagName string) bool {
@@ -34,3 +50,235 @@ func (attr *Attribute) tagValue(tagName string) bool {
 	}
 	return false
 }
+
+func (attr *Attribute) hasValue() bool {
+	return attr.ValueId != "" || attr.ValueName != "" || attr.ValueStruct != nil
+}
+
+func (attr *Attribute) value(valueId string) *Value {
+	for _, val := range attr.Values {
+		if val.Id == valueId {
+			return val
+		}
+	}
+	return nil
+}
+
+func (attr *Attribute) allowsUnit(unit string) bool {
+	if len(attr.AllowedUnits) == 0 {
+		return true
+	}
+	for _, allowed := range attr.AllowedUnits {
+		if allowed.Id == unit || allowed.Name == unit {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributeViolation is a single failure of an attribute against its category definition
+type AttributeViolation struct {
+	AttributeId string `json:"attribute_id,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Err         error  `json:"-"`
+}
+
+func (v *AttributeViolation) Error() string {
+	if v.Value == "" {
+		return fmt.Sprintf("%s: %v", v.AttributeId, v.Err)
+	}
+	return fmt.Sprintf("%s (%s): %v", v.AttributeId, v.Value, v.Err)
+}
+
+// AttributesReport gathers every violation found instead of stopping on the first one
+type AttributesReport struct {
+	Violations []*AttributeViolation `json:"violations,omitempty"`
+}
+
+func (r *AttributesReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// Err returns the report as an error, or nil in case of being valid
+func (r *AttributesReport) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return r
+}
+
+func (r *AttributesReport) Error() string {
+	var strErr string
+	for _, v := range r.Violations {
+		strErr += v.Error()
+		strErr += "; "
+	}
+	return strErr
+}
+
+func (r *AttributesReport) add(attr *Attribute, value string, err error) {
+	r.Violations = append(r.Violations, &AttributeViolation{AttributeId: attr.Id, Value: value, Err: err})
+}
+
+// ValidateProductAttributes validates the attributes of the product against the ones of its category
+func (ml *MeLi) ValidateProductAttributes(prod *Product) (*AttributesReport, error) {
+	if prod == nil {
+		return nil, ErrNilProduct
+	}
+	catAttrs, err := ml.CategoryAttributes(prod.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+	return prod.ValidateAttributes(catAttrs), nil
+}
+
+// ValidateAttributes checks the attributes of the product (and the attribute combinations of its variants)
+// against the given category attributes definitions
+func (prod *Product) ValidateAttributes(catAttrs []*Attribute) *AttributesReport {
+	report := &AttributesReport{}
+	for _, def := range catAttrs {
+		attrs := prod.attributes(def.Id)
+		if len(attrs) == 0 {
+			if def.tagValue("required") {
+				report.add(def, "", ErrMissingRequiredAttr)
+			} else if def.tagValue("catalog_required") {
+				report.add(def, "", ErrMissingCatalogRequiredAttr)
+			}
+			continue
+		}
+		for _, attr := range attrs {
+			def.validateValue(attr, report)
+		}
+	}
+	return report
+}
+
+// attributes retrieves every valued attribute of the product with the given id
+func (prod *Product) attributes(attrId string) (attrs []*Attribute) {
+	for _, attr := range prod.Attributes {
+		if attr.Id == attrId && attr.hasValue() {
+			attrs = append(attrs, attr)
+		}
+	}
+	for _, v := range prod.Variants {
+		for _, attr := range v.AttributeCombinations {
+			if attr.Id == attrId && attr.hasValue() {
+				attrs = append(attrs, attr)
+			}
+		}
+	}
+	return
+}
+
+// defaultStringMaxLength is the max length of the string values whose definition doesn't state one
+const defaultStringMaxLength = 255
+
+func (def *Attribute) validateValue(attr *Attribute, report *AttributesReport) {
+	maxLength := def.ValueMaxLength
+	if maxLength == 0 && def.ValueType == "string" {
+		maxLength = defaultStringMaxLength
+	}
+	if maxLength > 0 && utf8.RuneCountInString(attr.ValueName) > maxLength {
+		report.add(def, attr.ValueName, ErrAttrValueTooLong)
+	}
+	if attr.ValueId != "" && len(def.Values) > 0 && def.value(attr.ValueId) == nil {
+		report.add(def, attr.ValueId, ErrInvalidAttrValueId)
+	}
+	if attr.ValueName == "" && attr.ValueStruct == nil {
+		return
+	}
+	switch def.ValueType {
+	case "number":
+		if _, err := attr.Number(); err != nil {
+			report.add(def, attr.ValueName, ErrInvalidAttrValueType)
+		}
+	case "number_unit":
+		nu, err := attr.NumberUnit()
+		if err != nil {
+			report.add(def, attr.ValueName, ErrInvalidAttrValueType)
+			return
+		}
+		if !def.allowsUnit(nu.Unit) {
+			report.add(def, attr.ValueName, ErrInvalidAttrUnit)
+		}
+	case "boolean":
+		if attr.ValueId == "" && len(def.Values) > 0 && !def.hasValueName(attr.ValueName) {
+			report.add(def, attr.ValueName, ErrInvalidAttrValueType)
+		}
+	case "list":
+		if attr.ValueId == "" && len(def.Values) > 0 && !def.hasValueName(attr.ValueName) {
+			report.add(def, attr.ValueName, ErrInvalidAttrValue)
+		}
+	}
+}
+
+func (attr *Attribute) hasValueName(name string) bool {
+	for _, val := range attr.Values {
+		if strings.EqualFold(val.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNumber parses numbers written with either . or , as decimal separator, and optionally the other one
+// as thousands separator (e.g. 1.234,56 or 1,234.56). A single separator is taken as the decimal one,
+// unless it's repeated (e.g. 1,234,567). Only an optional sign followed by digits and separators is accepted,
+// so the special forms strconv would parse (e.g. NaN, Inf, 1e5 or 0x1p3) are rejected
+func parseNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	unsigned := s
+	if strings.HasPrefix(unsigned, "-") || strings.HasPrefix(unsigned, "+") {
+		unsigned = unsigned[1:]
+	}
+	if !isPlainNumber(unsigned) {
+		return 0, ErrInvalidAttrValueType
+	}
+	decimal, thousands := ".", ","
+	lastDot, lastComma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case lastDot != -1 && lastComma != -1:
+		if lastComma > lastDot {
+			decimal, thousands = ",", "."
+		}
+	case lastComma != -1:
+		decimal, thousands = ",", "."
+		if strings.Count(s, ",") > 1 {
+			decimal, thousands = ".", ","
+		}
+	case strings.Count(s, ".") > 1:
+		decimal, thousands = ",", "."
+	}
+	intPart, fracPart := s, ""
+	if idx := strings.LastIndex(s, decimal); idx != -1 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+	if strings.Contains(fracPart, thousands) || strings.Contains(fracPart, decimal) {
+		return 0, ErrInvalidAttrValueType
+	}
+	groups := strings.Split(intPart, thousands)
+	for i, group := range groups {
+		if i > 0 && len(group) != 3 {
+			return 0, ErrInvalidAttrValueType
+		}
+	}
+	number := strings.Join(groups, "")
+	if fracPart != "" || strings.Contains(s, decimal) {
+		number += "." + fracPart
+	}
+	return strconv.ParseFloat(number, 64)
+}
+
+// isPlainNumber reports if s only has digits and separators, with at least a digit
+func isPlainNumber(s string) bool {
+	var digits int
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r != '.' && r != ',':
+			return false
+		}
+	}
+	return digits > 0
+}
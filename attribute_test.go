@@ -0,0 +1,101 @@
+package meli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestProduct_ValidateAttributes(t *testing.T) {
+	t.Parallel()
+	catAttrs := []*Attribute{
+		{Id: "BRAND", ValueType: "string", ValueMaxLength: 5, Tags: []Tag{{"required": true}}},
+		{Id: "GTIN", ValueType: "string", Tags: []Tag{{"catalog_required": true}}},
+		{Id: "MODEL", ValueType: "string"},
+		{Id: "WEIGHT", ValueType: "number_unit", AllowedUnits: []*Unit{{Id: "g", Name: "g"}, {Id: "kg", Name: "kg"}}},
+		{Id: "PIECES", ValueType: "number"},
+		{Id: "COLOR", ValueType: "list", Values: []*Value{{Id: "1", Name: "Red"}}, Tags: []Tag{{"allow_variations": true}}},
+		{Id: "MATERIAL", ValueType: "list", Values: []*Value{{Id: "1", Name: "Wood"}, {Id: "2", Name: "Metal"}}},
+		{Id: "IS_KIT", ValueType: "boolean", Values: []*Value{{Id: "242085", Name: "Sí"}, {Id: "242084", Name: "No"}}},
+	}
+	tests := []struct {
+		name string
+		prod *Product
+		want []string
+	}{
+		{
+			name: "every attribute is VALID",
+			prod: &Product{
+				Attributes: []*Attribute{
+					{Id: "BRAND", ValueName: "Foo"},
+					{Id: "GTIN", ValueName: "123"},
+					{Id: "MODEL", ValueName: strings.Repeat("a", 255)},
+					{Id: "WEIGHT", ValueName: "1.234,5 g"},
+					{Id: "PIECES", ValueName: "1,234"},
+					{Id: "MATERIAL", ValueName: "metal"},
+					{Id: "IS_KIT", ValueName: "no"},
+				},
+				Variants: []*Variant{{AttributeCombinations: []*Attribute{{Id: "COLOR", ValueId: "1"}}}},
+			},
+		},
+		{
+			name: "number valued ONLY by its STRUCT",
+			prod: &Product{
+				Attributes: []*Attribute{
+					{Id: "BRAND", ValueName: "Foo"},
+					{Id: "GTIN", ValueName: "123"},
+					{Id: "PIECES", ValueStruct: &NumberUnit{Number: 3}},
+				},
+			},
+		},
+		{
+			name: "MISSING required attributes",
+			prod: &Product{},
+			want: []string{
+				fmt.Sprintf("BRAND: %v", ErrMissingRequiredAttr),
+				fmt.Sprintf("GTIN: %v", ErrMissingCatalogRequiredAttr),
+			},
+		},
+		{
+			name: "every attribute is INVALID",
+			prod: &Product{
+				Attributes: []*Attribute{
+					{Id: "BRAND", ValueName: "FooBar"},
+					{Id: "GTIN", ValueName: "123"},
+					{Id: "MODEL", ValueName: strings.Repeat("a", 256)},
+					{Id: "WEIGHT", ValueName: "3 lb"},
+					{Id: "PIECES", ValueName: "three"},
+					{Id: "MATERIAL", ValueName: "Plastic"},
+					{Id: "IS_KIT", ValueName: "maybe"},
+				},
+				Variants: []*Variant{{AttributeCombinations: []*Attribute{{Id: "COLOR", ValueId: "2"}}}},
+			},
+			want: []string{
+				fmt.Sprintf("BRAND (FooBar): %v", ErrAttrValueTooLong),
+				fmt.Sprintf("MODEL (%v): %v", strings.Repeat("a", 256), ErrAttrValueTooLong),
+				fmt.Sprintf("WEIGHT (3 lb): %v", ErrInvalidAttrUnit),
+				fmt.Sprintf("PIECES (three): %v", ErrInvalidAttrValueType),
+				fmt.Sprintf("COLOR (2): %v", ErrInvalidAttrValueId),
+				fmt.Sprintf("MATERIAL (Plastic): %v", ErrInvalidAttrValue),
+				fmt.Sprintf("IS_KIT (maybe): %v", ErrInvalidAttrValueType),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			report := tt.prod.ValidateAttributes(catAttrs)
+			if report.Valid() != (len(tt.want) == 0) {
+				t.Errorf("AttributesReport.Valid() = %v, want %v", report.Valid(), len(tt.want) == 0)
+			}
+			var got []string
+			for _, v := range report.Violations {
+				got = append(got, v.Error())
+			}
+			if fmt.Sprintf("%v", got) != fmt.Sprintf("%v", tt.want) {
+				t.Errorf("Product.ValidateAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
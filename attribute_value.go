@@ -0,0 +1,228 @@
+package meli
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Value ids used by MeLi on every boolean attribute
+const (
+	BoolTrueValueId  = "242085"
+	BoolFalseValueId = "242084"
+)
+
+// NumberUnit is the structured value of the number_unit attributes (e.g. 15 cm)
+type NumberUnit struct {
+	Number float64 `json:"number"`
+	Unit   string  `json:"unit"`
+}
+
+// ParseNumberUnit parses values such as "15 cm" or "1,5kg"
+func ParseNumberUnit(s string) (*NumberUnit, error) {
+	s = strings.TrimSpace(s)
+	idx := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == ',' || r == '-' || r == '+')
+	})
+	if idx <= 0 {
+		return nil, ErrInvalidAttrValueType
+	}
+	number, err := parseNumber(s[:idx])
+	if err != nil {
+		return nil, ErrInvalidAttrValueType
+	}
+	unit := strings.TrimSpace(s[idx:])
+	if unit == "" {
+		return nil, ErrInvalidAttrValueType
+	}
+	return &NumberUnit{Number: number, Unit: unit}, nil
+}
+
+func (nu *NumberUnit) String() string {
+	return strconv.FormatFloat(nu.Number, 'f', -1, 64) + " " + nu.Unit
+}
+
+type unitDef struct {
+	dimension string
+	factor    float64 // Relative to the base unit of its dimension
+}
+
+var units = map[string]unitDef{
+	"mm": {"length", 0.001}, "cm": {"length", 0.01}, "m": {"length", 1}, "km": {"length", 1000},
+	"in": {"length", 0.0254}, `"`: {"length", 0.0254}, "ft": {"length", 0.3048}, "yd": {"length", 0.9144},
+
+	"mg": {"mass", 0.001}, "g": {"mass", 1}, "kg": {"mass", 1000}, "t": {"mass", 1e6},
+	"oz": {"mass", 28.349523125}, "lb": {"mass", 453.59237},
+
+	"mL": {"volume", 0.001}, "cc": {"volume", 0.001}, "cm³": {"volume", 0.001}, "L": {"volume", 1}, "m³": {"volume", 1000},
+	"fl oz": {"volume", 0.0295735295625}, "gal": {"volume", 3.785411784},
+
+	"B": {"data", 1}, "KB": {"data", 1 << 10}, "MB": {"data", 1 << 20}, "GB": {"data", 1 << 30}, "TB": {"data", 1 << 40},
+
+	"W": {"power", 1}, "kW": {"power", 1000}, "hp": {"power", 745.699872},
+
+	"Hz": {"frequency", 1}, "kHz": {"frequency", 1e3}, "MHz": {"frequency", 1e6}, "GHz": {"frequency", 1e9},
+
+	"mAh": {"charge", 0.001}, "Ah": {"charge", 1},
+
+	"mV": {"voltage", 0.001}, "V": {"voltage", 1}, "kV": {"voltage", 1000},
+
+	"ms": {"time", 0.001}, "s": {"time", 1}, "min": {"time", 60}, "h": {"time", 3600},
+}
+
+// Convert expresses the value on the given unit, as long as both units measure the same dimension
+func (nu *NumberUnit) Convert(unit string) (*NumberUnit, error) {
+	if nu.Unit == unit {
+		return &NumberUnit{Number: nu.Number, Unit: unit}, nil
+	}
+	from, ok := units[nu.Unit]
+	if !ok {
+		return nil, ErrUnknownUnit
+	}
+	to, ok := units[unit]
+	if !ok {
+		return nil, ErrUnknownUnit
+	}
+	if from.dimension != to.dimension {
+		return nil, ErrIncompatibleUnits
+	}
+	return &NumberUnit{Number: nu.Number * from.factor / to.factor, Unit: unit}, nil
+}
+
+// NumberUnit reads the value of a number_unit attribute, preferring its value_struct over its value_name
+func (attr *Attribute) NumberUnit() (*NumberUnit, error) {
+	if attr.ValueStruct != nil {
+		return attr.ValueStruct, nil
+	}
+	return ParseNumberUnit(attr.ValueName)
+}
+
+// NumberUnit reads the struct of the value as the one of a number_unit attribute
+func (val *Value) NumberUnit() (*NumberUnit, error) {
+	if val.Struct == nil {
+		return nil, ErrNilAttrValue
+	}
+	content, err := json.Marshal(val.Struct)
+	if err != nil {
+		return nil, err
+	}
+	nu := &NumberUnit{}
+	err = json.Unmarshal(content, nu)
+	if err != nil || nu.Unit == "" {
+		return nil, ErrInvalidAttrValueType
+	}
+	return nu, nil
+}
+
+// Number reads the value of a number attribute, falling back to its value_struct lacking its value_name
+func (attr *Attribute) Number() (float64, error) {
+	if attr.ValueName == "" && attr.ValueStruct != nil {
+		return attr.ValueStruct.Number, nil
+	}
+	number, err := parseNumber(attr.ValueName)
+	if err != nil {
+		return 0, ErrInvalidAttrValueType
+	}
+	return number, nil
+}
+
+// Bool reads the value of a boolean attribute
+func (attr *Attribute) Bool() (bool, error) {
+	switch attr.ValueId {
+	case BoolTrueValueId:
+		return true, nil
+	case BoolFalseValueId:
+		return false, nil
+	}
+	switch strings.ToLower(attr.ValueName) {
+	case "sí", "si", "sim", "yes", "true":
+		return true, nil
+	case "no", "não", "nao", "false":
+		return false, nil
+	}
+	return false, ErrInvalidAttrValueType
+}
+
+// NumberUnitValue builds a valued attribute from its definition (i.e. the category attribute).
+// In case the unit is not allowed by the definition, it's converted to the default (or the first convertible) unit
+func (def *Attribute) NumberUnitValue(nu *NumberUnit) (*Attribute, error) {
+	if def.ValueType != "number_unit" {
+		return nil, ErrInvalidAttrValueType
+	}
+	if nu == nil {
+		return nil, ErrNilAttrValue
+	}
+	if !def.allowsUnit(nu.Unit) {
+		var err error
+		nu, err = def.convertToAllowedUnit(nu)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Attribute{Id: def.Id, ValueName: nu.String(), ValueStruct: nu}, nil
+}
+
+func (def *Attribute) convertToAllowedUnit(nu *NumberUnit) (*NumberUnit, error) {
+	candidates := []string{def.DefaultUnit}
+	for _, unit := range def.AllowedUnits {
+		candidates = append(candidates, unit.Id)
+	}
+	for _, unit := range candidates {
+		if unit == "" || !def.allowsUnit(unit) {
+			continue
+		}
+		converted, err := nu.Convert(unit)
+		if err == nil {
+			return converted, nil
+		}
+	}
+	return nil, ErrInvalidAttrUnit
+}
+
+// NumberValue builds a valued number attribute from its definition
+func (def *Attribute) NumberValue(number float64) (*Attribute, error) {
+	if def.ValueType != "number" {
+		return nil, ErrInvalidAttrValueType
+	}
+	return &Attribute{Id: def.Id, ValueName: strconv.FormatFloat(number, 'f', -1, 64)}, nil
+}
+
+// BoolValue builds a valued boolean attribute from its definition
+func (def *Attribute) BoolValue(b bool) (*Attribute, error) {
+	if def.ValueType != "boolean" {
+		return nil, ErrInvalidAttrValueType
+	}
+	valueId := BoolFalseValueId
+	if b {
+		valueId = BoolTrueValueId
+	}
+	attr := &Attribute{Id: def.Id, ValueId: valueId}
+	if val := def.value(valueId); val != nil {
+		attr.ValueName = val.Name
+	}
+	return attr, nil
+}
+
+// ListValue builds a valued list attribute from its definition, given one of its allowed value ids
+func (def *Attribute) ListValue(valueId string) (*Attribute, error) {
+	if def.ValueType != "list" {
+		return nil, ErrInvalidAttrValueType
+	}
+	val := def.value(valueId)
+	if val == nil {
+		return nil, ErrInvalidAttrValueId
+	}
+	return &Attribute{Id: def.Id, ValueId: val.Id, ValueName: val.Name}, nil
+}
+
+// StringValue builds a valued string attribute from its definition
+func (def *Attribute) StringValue(s string) (*Attribute, error) {
+	if def.ValueType != "string" {
+		return nil, ErrInvalidAttrValueType
+	}
+	if def.ValueMaxLength > 0 && utf8.RuneCountInString(s) > def.ValueMaxLength {
+		return nil, ErrAttrValueTooLong
+	}
+	return &Attribute{Id: def.Id, ValueName: s}, nil
+}
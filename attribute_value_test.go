@@ -0,0 +1,197 @@
+package meli
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseNumberUnit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    *NumberUnit
+		wantErr error
+	}{
+		{name: "spaced", s: "15 cm", want: &NumberUnit{Number: 15, Unit: "cm"}},
+		{name: "glued with comma decimals", s: "1,5kg", want: &NumberUnit{Number: 1.5, Unit: "kg"}},
+		{name: "multi word unit", s: " 12 fl oz ", want: &NumberUnit{Number: 12, Unit: "fl oz"}},
+		{name: "NO unit", s: "15", wantErr: ErrInvalidAttrValueType},
+		{name: "NO number", s: "cm", wantErr: ErrInvalidAttrValueType},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseNumberUnit(tt.s)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("ParseNumberUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseNumberUnit() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValue_NumberUnit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		json    string
+		want    *NumberUnit
+		wantErr error
+	}{
+		{name: "number unit STRUCT", json: `{"id":"1","struct":{"number":15,"unit":"cm"}}`, want: &NumberUnit{Number: 15, Unit: "cm"}},
+		{name: "NIL struct", json: `{"id":"1","name":"Red"}`, wantErr: ErrNilAttrValue},
+		{name: "OTHER struct", json: `{"id":"1","struct":{"foo":"bar"}}`, wantErr: ErrInvalidAttrValueType},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			val := &Value{}
+			if err := json.Unmarshal([]byte(tt.json), val); err != nil {
+				t.Fatalf("couldn't unmarshal the value: %v", err)
+			}
+			got, err := val.NumberUnit()
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("Value.NumberUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Value.NumberUnit() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    float64
+		wantErr bool
+	}{
+		{name: "integer", s: "15", want: 15},
+		{name: "dot decimals", s: "1.5", want: 1.5},
+		{name: "comma decimals", s: "1,5", want: 1.5},
+		{name: "dot thousands and comma decimals", s: "1.234,56", want: 1234.56},
+		{name: "comma thousands and dot decimals", s: "1,234.56", want: 1234.56},
+		{name: "repeated comma thousands", s: "1,234,567", want: 1234567},
+		{name: "repeated dot thousands", s: "1.234.567", want: 1234567},
+		{name: "negative", s: " -1.234,5 ", want: -1234.5},
+		{name: "MISPLACED thousands", s: "1,23,4", wantErr: true},
+		{name: "thousands AFTER decimals", s: "1,5.234.5", wantErr: true},
+		{name: "NOT a number", s: "three", wantErr: true},
+		{name: "NaN", s: "NaN", wantErr: true},
+		{name: "INFINITY", s: "Inf", wantErr: true},
+		{name: "SIGNED INFINITY", s: "+Inf", wantErr: true},
+		{name: "EXPONENT", s: "1e5", wantErr: true},
+		{name: "HEXADECIMAL", s: "0x1p3", wantErr: true},
+		{name: "DOUBLE sign", s: "--1", wantErr: true},
+		{name: "only SEPARATORS", s: "-.,", wantErr: true},
+		{name: "positive sign", s: "+1,5", want: 1.5},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseNumber(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseNumber() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttribute_NumberUnitValue(t *testing.T) {
+	t.Parallel()
+	def := &Attribute{Id: "WIDTH", ValueType: "number_unit", DefaultUnit: "cm",
+		AllowedUnits: []*Unit{{Id: "cm", Name: "cm"}, {Id: "m", Name: "m"}},
+	}
+	tests := []struct {
+		name    string
+		def     *Attribute
+		nu      *NumberUnit
+		want    *Attribute
+		wantErr error
+	}{
+		{
+			name: "ALLOWED unit",
+			def:  def,
+			nu:   &NumberUnit{Number: 2, Unit: "m"},
+			want: &Attribute{Id: "WIDTH", ValueName: "2 m", ValueStruct: &NumberUnit{Number: 2, Unit: "m"}},
+		},
+		{
+			name: "CONVERTED to the default unit",
+			def:  def,
+			nu:   &NumberUnit{Number: 15, Unit: "mm"},
+			want: &Attribute{Id: "WIDTH", ValueName: "1.5 cm", ValueStruct: &NumberUnit{Number: 1.5, Unit: "cm"}},
+		},
+		{
+			name:    "INCOMPATIBLE unit",
+			def:     def,
+			nu:      &NumberUnit{Number: 15, Unit: "kg"},
+			wantErr: ErrInvalidAttrUnit,
+		},
+		{
+			name:    "NOT a number_unit attribute",
+			def:     &Attribute{Id: "BRAND", ValueType: "string"},
+			nu:      &NumberUnit{Number: 15, Unit: "cm"},
+			wantErr: ErrInvalidAttrValueType,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.def.NumberUnitValue(tt.nu)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("Attribute.NumberUnitValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Attribute.NumberUnitValue() mismatch (-want +got): %s", diff)
+			}
+			if got == nil {
+				return
+			}
+			roundTripped := &Attribute{}
+			err = json.Unmarshal(JSONMarshal(t, got), roundTripped)
+			if err != nil {
+				t.Fatalf("couldn't unmarshal the attribute: %v", err)
+			}
+			nu, err := roundTripped.NumberUnit()
+			if err != nil {
+				t.Fatalf("Attribute.NumberUnit() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want.ValueStruct, nu); diff != "" {
+				t.Errorf("Attribute.NumberUnit() round trip mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestAttribute_Bool(t *testing.T) {
+	t.Parallel()
+	def := &Attribute{Id: "IS_KIT", ValueType: "boolean", Values: []*Value{{Id: BoolTrueValueId, Name: "Sí"}, {Id: BoolFalseValueId, Name: "No"}}}
+	for _, want := range []bool{true, false} {
+		attr, err := def.BoolValue(want)
+		if err != nil {
+			t.Fatalf("Attribute.BoolValue() error = %v", err)
+		}
+		got, err := attr.Bool()
+		if err != nil {
+			t.Fatalf("Attribute.Bool() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Attribute.Bool() = %v, want %v", got, want)
+		}
+	}
+}
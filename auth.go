@@ -47,6 +47,7 @@ func (ml *MeLi) RefreshToken() error {
 	}
 	ml.creds.Access = body.AccessToken
 	ml.creds.Refresh = body.RefreshToken
+	ml.creds.UserId = body.UserId
 	return nil
 }
 
@@ -87,6 +88,7 @@ func (ml *MeLi) SetCredentialsFromCode(code string, redirectURI string) error {
 	}
 	ml.creds.Access = body.AccessToken
 	ml.creds.Refresh = body.RefreshToken
+	ml.creds.UserId = body.UserId
 	return nil
 }
 
@@ -0,0 +1,251 @@
+package meli
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache is the storage used to keep the responses of rarely changing resources
+// (e.g. category details, category attributes or listing types)
+type Cache interface {
+	// Get retrieves the entry of the given key, even if it's expired (so it can be revalidated)
+	Get(key string) (entry *CacheEntry, ok bool)
+	// Set stores the entry, assigning its expiration
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+type CacheEntry struct {
+	Body    []byte    `json:"body,omitempty"`
+	ETag    string    `json:"etag,omitempty"`
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+func (e *CacheEntry) expired() bool {
+	return !time.Now().Before(e.Expires)
+}
+
+// CacheStats are the metrics of the cached requests performed by the client.
+// The requests performed without a cache set aren't counted
+type CacheStats struct {
+	Hits        int64 // Served directly from the cache
+	Revalidated int64 // Served from the cache after the server confirmed it's unmodified
+	Misses      int64 // Fully retrieved from the server
+}
+
+// HitRate is the proportion of requests which didn't need to download the resource
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Revalidated + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.Revalidated) / float64(total)
+}
+
+// SetCache plugs the given cache on the client. A nil cache disables caching
+func (ml *MeLi) SetCache(c Cache) {
+	ml.cache = c
+}
+
+// CacheStats retrieves a snapshot of the cache metrics
+func (ml *MeLi) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:        atomic.LoadInt64(&ml.cacheStats.Hits),
+		Revalidated: atomic.LoadInt64(&ml.cacheStats.Revalidated),
+		Misses:      atomic.LoadInt64(&ml.cacheStats.Misses),
+	}
+}
+
+// getCached performs a GET to the given URL, serving it from the cache in case of being fresh.
+// Once expired, the entry is revalidated by its ETag (If-None-Match)
+func (ml *MeLi) getCached(URL string) ([]byte, error) {
+	var entry *CacheEntry
+	var ok bool
+	key := cacheKey(URL)
+	if ml.cache != nil {
+		entry, ok = ml.cache.Get(key)
+	}
+	if ok && !entry.expired() {
+		atomic.AddInt64(&ml.cacheStats.Hits, 1)
+		return entry.Body, nil
+	}
+
+	req, err := http.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if ok && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	resp, err := ml.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if ok && resp.StatusCode == http.StatusNotModified {
+		atomic.AddInt64(&ml.cacheStats.Revalidated, 1)
+		ml.cache.Set(key, &CacheEntry{Body: entry.Body, ETag: entry.ETag})
+		return entry.Body, nil
+	}
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if ml.cache != nil {
+		atomic.AddInt64(&ml.cacheStats.Misses, 1)
+		ml.cache.Set(key, &CacheEntry{Body: body, ETag: resp.Header.Get("ETag")})
+	}
+	return body, nil
+}
+
+// cacheKey strips the credentials of the URL, so they don't end up on the cache storage
+func cacheKey(URL string) string {
+	u, err := url.Parse(URL)
+	if err != nil {
+		return URL
+	}
+	params := u.Query()
+	params.Del("access_token")
+	u.RawQuery = params.Encode()
+	return u.String()
+}
+
+// MemoryCache is an in-memory LRU cache whose entries expire after its TTL
+type MemoryCache struct {
+	size int
+	ttl  time.Duration
+
+	entries map[string]*list.Element
+	lru     *list.List
+	lock    sync.Mutex
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache creates a cache which keeps up to size entries (unbounded if size <= 0)
+func NewMemoryCache(size int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{size: size, ttl: ttl, entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+func (c *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*memoryCacheItem).entry, true
+}
+
+func (c *MemoryCache) Set(key string, entry *CacheEntry) {
+	entry.Expires = time.Now().Add(c.ttl)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*memoryCacheItem).entry = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&memoryCacheItem{key: key, entry: entry})
+	if c.size > 0 && c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+func (c *MemoryCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// DiskCache persists each entry as a file on its dir, so it survives between runs
+type DiskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewDiskCache creates the given dir in case it does not exists
+func NewDiskCache(dir string, ttl time.Duration) (*DiskCache, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir, ttl: ttl}, nil
+}
+
+func (c *DiskCache) Get(key string) (*CacheEntry, bool) {
+	content, err := ioutil.ReadFile(c.filename(key))
+	if err != nil {
+		return nil, false
+	}
+	entry := &CacheEntry{}
+	err = json.Unmarshal(content, entry)
+	if err != nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Set is best-effort: in case of failing to write, the entry is simply not cached
+func (c *DiskCache) Set(key string, entry *CacheEntry) {
+	entry.Expires = time.Now().Add(c.ttl)
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// Each write has its own temp file, so concurrent ones of the same key don't overwrite each other's
+	tmp, err := ioutil.TempFile(c.dir, "*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.filename(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func (c *DiskCache) Delete(key string) {
+	os.Remove(c.filename(key))
+}
+
+func (c *DiskCache) filename(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
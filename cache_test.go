@@ -0,0 +1,208 @@
+package meli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestMemoryCache_Set(t *testing.T) {
+	t.Parallel()
+	c := NewMemoryCache(2, time.Minute)
+	c.Set("foo", &CacheEntry{Body: []byte("foo")})
+	c.Set("bar", &CacheEntry{Body: []byte("bar")})
+	c.Get("foo") // bar becomes the least recently used
+	c.Set("baz", &CacheEntry{Body: []byte("baz")})
+
+	if _, ok := c.Get("bar"); ok {
+		t.Errorf("MemoryCache.Set() did NOT EVICT the least recently used entry")
+	}
+	for _, key := range []string{"foo", "baz"} {
+		entry, ok := c.Get(key)
+		if !ok {
+			t.Fatalf("MemoryCache.Set() EVICTED the %v entry", key)
+		}
+		if string(entry.Body) != key {
+			t.Errorf("MemoryCache.Get() = %s, want %v", entry.Body, key)
+		}
+		if entry.expired() {
+			t.Errorf("MemoryCache.Set() entry %v is EXPIRED before its TTL", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("MemoryCache.Len() = %v, want %v", c.Len(), 2)
+	}
+}
+
+func TestDiskCache_Set(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "meli")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	c, err := NewDiskCache(dir, time.Minute)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	c.Set("foo", &CacheEntry{Body: []byte("foo"), ETag: `"foo"`})
+
+	entry, ok := c.Get("foo")
+	if !ok {
+		t.Fatalf("DiskCache.Get() did NOT FIND the set entry")
+	}
+	if string(entry.Body) != "foo" || entry.ETag != `"foo"` {
+		t.Errorf("DiskCache.Get() = %s (etag %v), want foo (etag %v)", entry.Body, entry.ETag, `"foo"`)
+	}
+	if entry.expired() {
+		t.Errorf("DiskCache.Set() entry is EXPIRED before its TTL")
+	}
+
+	// Another cache on the same dir reads the entries persisted by the previous one
+	expiring, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	if _, ok := expiring.Get("foo"); !ok {
+		t.Errorf("DiskCache.Get() did NOT FIND the entry persisted by another cache")
+	}
+	expiring.Set("bar", &CacheEntry{Body: []byte("bar")})
+	entry, ok = expiring.Get("bar")
+	if !ok {
+		t.Fatalf("DiskCache.Get() did NOT FIND the set entry")
+	}
+	if !entry.expired() {
+		t.Errorf("DiskCache.Set() entry is NOT EXPIRED after its TTL")
+	}
+
+	// Concurrent writes of the same key leave a whole entry, without temp files behind
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Set("baz", &CacheEntry{Body: bytes.Repeat([]byte{'a' + byte(i)}, 1<<12)})
+		}(i)
+	}
+	wg.Wait()
+	entry, ok = c.Get("baz")
+	if !ok || len(entry.Body) != 1<<12 {
+		t.Errorf("DiskCache.Set() concurrently left a CORRUPTED entry")
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(tmps) != 0 {
+		t.Errorf("DiskCache.Set() left the temp files %v", tmps)
+	}
+
+	c.Delete("foo")
+	if _, ok := c.Get("foo"); ok {
+		t.Errorf("DiskCache.Delete() did NOT DELETE the entry")
+	}
+}
+
+func TestMeLi_getCached(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		noCache   bool
+		onDisk    bool
+		ttl       time.Duration
+		wantReqs  int
+		wantStats CacheStats
+	}{
+		{
+			name:      "FRESH entries are served from cache",
+			ttl:       time.Minute,
+			wantReqs:  1,
+			wantStats: CacheStats{Misses: 1, Hits: 2},
+		},
+		{
+			name:      "EXPIRED entries are revalidated by etag",
+			ttl:       0,
+			wantReqs:  3,
+			wantStats: CacheStats{Misses: 1, Revalidated: 2},
+		},
+		{
+			name:     "requests WITHOUT CACHE aren't counted",
+			noCache:  true,
+			wantReqs: 3,
+		},
+		{
+			name:      "FRESH entries are served from DISK cache",
+			onDisk:    true,
+			ttl:       time.Minute,
+			wantReqs:  1,
+			wantStats: CacheStats{Misses: 1, Hits: 2},
+		},
+		{
+			name:      "EXPIRED entries are revalidated by etag from DISK cache",
+			onDisk:    true,
+			ttl:       0,
+			wantReqs:  3,
+			wantStats: CacheStats{Misses: 1, Revalidated: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var reqs int
+			ml := &MeLi{}
+			if !tt.noCache {
+				ml.SetCache(NewMemoryCache(0, tt.ttl))
+			}
+			if tt.onDisk {
+				dir, err := ioutil.TempDir("", "meli")
+				if err != nil {
+					t.Fatalf("couldn't create temp dir: %v", err)
+				}
+				defer os.RemoveAll(dir)
+				c, err := NewDiskCache(dir, tt.ttl)
+				if err != nil {
+					t.Fatalf("NewDiskCache() error = %v", err)
+				}
+				ml.SetCache(c)
+			}
+			ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				reqs++
+				resp := &http.Response{Header: http.Header{}, Body: ioutil.NopCloser(&bytes.Buffer{})}
+				if req.Header.Get("If-None-Match") == `"foo"` {
+					resp.StatusCode = http.StatusNotModified
+					return resp, nil
+				}
+				resp.StatusCode = http.StatusOK
+				resp.Header.Set("ETag", `"foo"`)
+				resp.Body = ioutil.NopCloser(bytes.NewBufferString(`[{"id":"foo"}]`))
+				return resp, nil
+			})})
+
+			for i := 0; i < 3; i++ {
+				got, err := ml.CategoryAttributes("bar")
+				if err != nil {
+					t.Fatalf("MeLi.CategoryAttributes() error = %v", err)
+				}
+				if diff := cmp.Diff([]*Attribute{{Id: "foo"}}, got); diff != "" {
+					t.Errorf("MeLi.CategoryAttributes() mismatch (-want +got): %s", diff)
+				}
+			}
+			if reqs != tt.wantReqs {
+				t.Errorf("MeLi.getCached() performed %v requests, want %v", reqs, tt.wantReqs)
+			}
+			if diff := cmp.Diff(tt.wantStats, ml.CacheStats()); diff != "" {
+				t.Errorf("MeLi.CacheStats() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
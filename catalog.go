@@ -0,0 +1,195 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+type CatalogProductId string
+
+type CatalogEligibilityStatus string
+
+const (
+	CatalogReadyForOptIn   CatalogEligibilityStatus = "READY_FOR_OPTIN"
+	CatalogAlreadyOptedIn  CatalogEligibilityStatus = "ALREADY_OPTED_IN"
+	CatalogNotEligible     CatalogEligibilityStatus = "NOT_ELIGIBLE"
+	CatalogClosed          CatalogEligibilityStatus = "CLOSED"
+	CatalogProductInactive CatalogEligibilityStatus = "PRODUCT_INACTIVE"
+)
+
+// CatalogEligibility reports if an item (and each of its variants) can be listed on the catalog
+type CatalogEligibility struct {
+	Id             ProductId                `json:"id"`
+	SiteId         SiteId                   `json:"site_id,omitempty"`
+	DomainId       string                   `json:"domain_id,omitempty"`
+	Status         CatalogEligibilityStatus `json:"status"`
+	BuyBoxEligible bool                     `json:"buy_box_eligible"`
+	Variations     []*struct {
+		Id             VariantId                `json:"id"`
+		Status         CatalogEligibilityStatus `json:"status"`
+		BuyBoxEligible bool                     `json:"buy_box_eligible"`
+	} `json:"variations,omitempty"`
+}
+
+// CanOptIn reports if the item (or the given variant of it, if isn't nil) is ready to opt in to the catalog
+func (elig *CatalogEligibility) CanOptIn(variantId VariantId) bool {
+	if variantId == 0 {
+		return elig.Status == CatalogReadyForOptIn
+	}
+	for _, v := range elig.Variations {
+		if v.Id == variantId {
+			return v.Status == CatalogReadyForOptIn
+		}
+	}
+	return false
+}
+
+func (ml *MeLi) CatalogEligibility(itemId ProductId) (*CatalogEligibility, error) {
+	if itemId == "" {
+		return nil, ErrNilProductId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/items/%v/catalog_listing_eligibility", params, itemId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	elig := &CatalogEligibility{}
+	err = json.NewDecoder(resp.Body).Decode(elig)
+	if err != nil {
+		return nil, err
+	}
+	return elig, nil
+}
+
+// CatalogProduct is a product of the catalog, which many sellers' listings compete to sell
+type CatalogProduct struct {
+	Id         CatalogProductId `json:"id"`
+	Status     string           `json:"status,omitempty"`
+	DomainId   string           `json:"domain_id,omitempty"`
+	Name       string           `json:"name,omitempty"`
+	ParentId   CatalogProductId `json:"parent_id,omitempty"`
+	Attributes []*Attribute     `json:"attributes,omitempty"`
+	Pictures   []*Picture       `json:"pictures,omitempty"`
+}
+
+// CatalogSearch filters the products of the catalog of a site, by its identifier (e.g. GTIN) or a query
+type CatalogSearch struct {
+	SiteId            SiteId
+	ProductIdentifier string
+	Q                 string
+	DomainId          string
+	Status            string // e.g. active
+	Offset            int
+	Limit             int
+}
+
+type CatalogProductEdge struct {
+	Keywords string            `json:"keywords,omitempty"`
+	Paging   Paging            `json:"paging"`
+	Results  []*CatalogProduct `json:"results"`
+}
+
+func (ml *MeLi) SearchCatalogProducts(search *CatalogSearch) (*CatalogProductEdge, error) {
+	if search == nil || search.SiteId == "" {
+		return nil, ErrNilSiteId
+	}
+	if search.ProductIdentifier == "" && search.Q == "" {
+		return nil, ErrNilCatalogSearch
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("site_id", string(search.SiteId))
+	if search.ProductIdentifier != "" {
+		params.Set("product_identifier", search.ProductIdentifier)
+	}
+	if search.Q != "" {
+		params.Set("q", search.Q)
+	}
+	if search.DomainId != "" {
+		params.Set("domain_id", search.DomainId)
+	}
+	if search.Status != "" {
+		params.Set("status", search.Status)
+	}
+	if search.Offset > 0 {
+		params.Set("offset", strconv.Itoa(search.Offset))
+	}
+	if search.Limit > 0 {
+		params.Set("limit", strconv.Itoa(search.Limit))
+	}
+	URL, err := ml.RouteTo("/products/search", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &CatalogProductEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+type catalogOptIn struct {
+	ItemId           ProductId        `json:"item_id"`
+	CatalogProductId CatalogProductId `json:"catalog_product_id"`
+	VariationId      VariantId        `json:"variation_id,omitempty"`
+}
+
+// OptInToCatalog creates a catalog listing linked to the given item (or to a variant of it, if isn't nil),
+// which shares its stock. It retrieves the new listing
+func (ml *MeLi) OptInToCatalog(itemId ProductId, catalogProdId CatalogProductId, variantId VariantId) (*Product, error) {
+	if itemId == "" {
+		return nil, ErrNilProductId
+	}
+	if catalogProdId == "" {
+		return nil, ErrNilCatalogProductId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/items/catalog_listings", params)
+	if err != nil {
+		return nil, err
+	}
+	reqBody, err := json.Marshal(&catalogOptIn{ItemId: itemId, CatalogProductId: catalogProdId, VariationId: variantId})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Post(URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	prod := &Product{}
+	err = json.NewDecoder(resp.Body).Decode(prod)
+	if err != nil {
+		return nil, err
+	}
+	return prod, nil
+}
@@ -0,0 +1,156 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_SearchCatalogProducts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		search   *CatalogSearch
+		stub     *httpstub.Stub
+		wantEdge *CatalogProductEdge
+		wantErr  error
+	}{
+		{
+			name:    "NIL SITE ID",
+			search:  &CatalogSearch{ProductIdentifier: "7790001"},
+			wantErr: ErrNilSiteId,
+		},
+		{
+			name:    "NIL IDENTIFIER NOR QUERY",
+			search:  &CatalogSearch{SiteId: "MLA", DomainId: "MLA-CELLPHONES"},
+			wantErr: ErrNilCatalogSearch,
+		},
+		{
+			name:   "REMOTE returns an ERR",
+			search: &CatalogSearch{SiteId: "MLA", Q: "foo"},
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/products/search",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"}, "site_id": []string{"MLA"}, "q": []string{"foo"},
+				}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "REMOTE returns CORRECTly by GTIN",
+			search: &CatalogSearch{SiteId: "MLA", ProductIdentifier: "7790001", Status: "active"},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/products/search",
+				Body: &CatalogProductEdge{Paging: Paging{Total: 1}, Results: []*CatalogProduct{
+					{Id: "MLA123", Status: "active", Name: "bar", Attributes: []*Attribute{{Id: "GTIN", ValueName: "7790001"}}},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token":       []string{"foo"},
+					"site_id":            []string{"MLA"},
+					"product_identifier": []string{"7790001"},
+					"status":             []string{"active"},
+				}},
+			},
+			wantEdge: &CatalogProductEdge{Paging: Paging{Total: 1}, Results: []*CatalogProduct{
+				{Id: "MLA123", Status: "active", Name: "bar", Attributes: []*Attribute{{Id: "GTIN", ValueName: "7790001"}}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotEdge, err := ml.SearchCatalogProducts(tt.search)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.SearchCatalogProducts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantEdge, gotEdge); diff != "" {
+				t.Errorf("MeLi.SearchCatalogProducts() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_OptInToCatalog(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		itemId        ProductId
+		catalogProdId CatalogProductId
+		variantId     VariantId
+		stub          *httpstub.Stub
+		wantId        ProductId
+		wantErr       error
+	}{
+		{
+			name:          "NIL ITEM ID",
+			catalogProdId: "MLA123",
+			wantErr:       ErrNilProductId,
+		},
+		{
+			name:    "NIL CATALOG PRODUCT ID",
+			itemId:  "MLA1",
+			wantErr: ErrNilCatalogProductId,
+		},
+		{
+			name:          "REMOTE returns an ERR",
+			itemId:        "MLA1",
+			catalogProdId: "MLA123",
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/items/catalog_listings",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{
+					Params: url.Values{"access_token": []string{"foo"}},
+					Body:   []byte(`{"item_id":"MLA1","catalog_product_id":"MLA123"}`),
+				},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:          "REMOTE returns CORRECTly",
+			itemId:        "MLA1",
+			catalogProdId: "MLA123",
+			variantId:     5,
+			stub: &httpstub.Stub{Status: 201,
+				URL:  "/items/catalog_listings",
+				Body: &Product{Id: "MLA2", CatalogListing: true, CatalogProductId: "MLA123"},
+				Receive: httpstub.Receive{
+					Params: url.Values{"access_token": []string{"foo"}},
+					Body:   []byte(`{"item_id":"MLA1","catalog_product_id":"MLA123","variation_id":5}`),
+				},
+			},
+			wantId: "MLA2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotProd, err := ml.OptInToCatalog(tt.itemId, tt.catalogProdId, tt.variantId)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.OptInToCatalog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var gotId ProductId
+			if gotProd != nil {
+				gotId = gotProd.Id
+			}
+			if gotId != tt.wantId {
+				t.Errorf("MeLi.OptInToCatalog() = %v, want %v", gotId, tt.wantId)
+			}
+		})
+	}
+}
@@ -2,15 +2,50 @@ package meli
 
 import (
 	"encoding/json"
+	"strings"
 )
 
 type Category struct {
-	Id                    CategoryId   `json:"id,omitempty"`
-	Name                  string       `json:"name,omitempty"`
-	PredictionProbability float64      `json:"prediction_probability,omitempty"`
-	ShippingModes         []string     `json:"shipping_modes,omitempty"`
-	PathFromRoot          []*Category  `json:"path_from_root,omitempty"`
-	Variations            []*Attribute `json:"variations,omitempty"`
+	Id                    CategoryId     `json:"id,omitempty"`
+	Name                  string         `json:"name,omitempty"`
+	PredictionProbability float64        `json:"prediction_probability,omitempty"`
+	ShippingModes         []ShippingMode `json:"shipping_modes,omitempty"`
+	PathFromRoot          []*Category    `json:"path_from_root,omitempty"`
+	Variations            []*Attribute   `json:"variations,omitempty"`
+
+	Picture                  string            `json:"picture,omitempty"`
+	Permalink                string            `json:"permalink,omitempty"`
+	TotalItemsInThisCategory int               `json:"total_items_in_this_category,omitempty"`
+	ChildrenCategories       []*Category       `json:"children_categories,omitempty"`
+	AttributeTypes           string            `json:"attribute_types,omitempty"`
+	Settings                 *CategorySettings `json:"settings,omitempty"`
+}
+
+type CategorySettings struct {
+	AdultContent          bool            `json:"adult_content,omitempty"`
+	BuyingAllowed         bool            `json:"buying_allowed,omitempty"`
+	BuyingModes           []BuyingMode    `json:"buying_modes,omitempty"`
+	CatalogDomain         string          `json:"catalog_domain,omitempty"`
+	Currencies            []string        `json:"currencies,omitempty"`
+	ItemConditions        []Condition     `json:"item_conditions,omitempty"`
+	ListingAllowed        bool            `json:"listing_allowed,omitempty"`
+	MaxDescriptionLength  int             `json:"max_description_length,omitempty"`
+	MaxPicturesPerItem    int             `json:"max_pictures_per_item,omitempty"`
+	MaxPicturesPerItemVar int             `json:"max_pictures_per_item_var,omitempty"`
+	MaxSubTitleLength     int             `json:"max_sub_title_length,omitempty"`
+	MaxTitleLength        int             `json:"max_title_length,omitempty"`
+	MaximumPrice          float64         `json:"maximum_price,omitempty"`
+	MinimumPrice          float64         `json:"minimum_price,omitempty"`
+	ShippingModes         []ShippingMode  `json:"shipping_modes,omitempty"`
+	ShippingOptions       []string        `json:"shipping_options,omitempty"`
+	Status                string          `json:"status,omitempty"`
+	Tags                  []string        `json:"tags,omitempty"`
+	ListingTypeIds        []ListingTypeId `json:"listing_type_ids,omitempty"`
+	MirrorCategory        string          `json:"mirror_category,omitempty"`
+	MirrorMasterCategory  string          `json:"mirror_master_category,omitempty"`
+	SellerContact         string          `json:"seller_contact,omitempty"`
+	ImmediatePayment      string          `json:"immediate_payment,omitempty"`
+	ItemsReviewsAllowed   bool            `json:"items_reviews_allowed,omitempty"`
 }
 
 type CategoryId string
@@ -23,16 +58,12 @@ func (ml *MeLi) CategoryAttributes(catId CategoryId) ([]*Attribute, error) {
 	if err != nil {
 		return nil, err
 	}
-	resp, err := ml.Get(URL)
+	body, err := ml.getCached(URL)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		return nil, errFromReader(resp.Body)
-	}
 	atts := []*Attribute{}
-	err = json.NewDecoder(resp.Body).Decode(&atts)
+	err = json.Unmarshal(body, &atts)
 	if err != nil {
 		return nil, err
 	}
@@ -52,3 +83,69 @@ func (ml *MeLi) CategoryVariableAttributes(catId CategoryId) ([]*Attribute, erro
 	}
 	return varAttrs, nil
 }
+
+// GetCategory retrieves the details of the given category, including its children and path from root
+func (ml *MeLi) GetCategory(catId CategoryId) (*Category, error) {
+	if catId == "" {
+		return nil, ErrNilCategoryId
+	}
+	URL, err := ml.RouteTo("/categories/%v", nil, catId)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ml.getCached(URL)
+	if err != nil {
+		return nil, err
+	}
+	cat := &Category{}
+	err = json.Unmarshal(body, cat)
+	if err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+// ListCategories retrieves the root categories of the given site
+func (ml *MeLi) ListCategories(siteId SiteId) ([]*Category, error) {
+	if siteId == "" {
+		return nil, ErrNilSiteId
+	}
+	URL, err := ml.RouteTo("/sites/%v/categories", nil, siteId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	cats := []*Category{}
+	err = json.NewDecoder(resp.Body).Decode(&cats)
+	if err != nil {
+		return nil, err
+	}
+	return cats, nil
+}
+
+// IsLeaf reports if the category has no children.
+// Notice it is only meaningful on categories retrieved with its details (e.g. by GetCategory)
+func (cat *Category) IsLeaf() bool {
+	return len(cat.ChildrenCategories) == 0
+}
+
+// Path returns the names of the categories from the root until the category itself
+func (cat *Category) Path() []string {
+	var path []string
+	for _, node := range cat.PathFromRoot {
+		path = append(path, node.Name)
+	}
+	return path
+}
+
+// PathString joins the path from root with the given separator
+func (cat *Category) PathString(sep string) string {
+	return strings.Join(cat.Path(), sep)
+}
@@ -130,3 +130,62 @@ func TestMeLi_CategoryVariableAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestMeLi_GetCategory(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		catId   CategoryId
+		want    *Category
+		wantErr error
+		stub    *httpstub.Stub
+	}{
+		{
+			name:    "NIL cat ID",
+			wantErr: ErrNilCategoryId,
+		},
+		{
+			name:    "REMOTE returns an ERR",
+			wantErr: svErrFooBar,
+			catId:   "foo",
+			stub: &httpstub.Stub{Status: 404,
+				URL:  "/categories/foo",
+				Body: svErrFooBar,
+			},
+		},
+		{
+			name:  "REMOTE returns CORRECTly",
+			catId: "foo",
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/categories/foo",
+				Body: &Category{Id: "foo", Name: "bar",
+					ChildrenCategories: []*Category{{Id: "baz", Name: "quux"}},
+					Settings:           &CategorySettings{MaxTitleLength: 60},
+				},
+			},
+			want: &Category{Id: "foo", Name: "bar",
+				ChildrenCategories: []*Category{{Id: "baz", Name: "quux"}},
+				Settings:           &CategorySettings{MaxTitleLength: 60},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			got, err := ml.GetCategory(tt.catId)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("MeLi.GetCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MeLi.GetCategory() = (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package meli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// CategoryTree is the browsable tree of categories of a site.
+// The children of each node are lazily loaded (and then kept) as they're needed,
+// unless the tree is fully loaded by Load or read from a dump.
+type CategoryTree struct {
+	SiteId     SiteId                   `json:"site_id,omitempty"`
+	Roots      []*Category              `json:"roots,omitempty"`
+	Categories map[CategoryId]*Category `json:"categories,omitempty"`
+
+	ml   *MeLi
+	lock sync.Mutex
+}
+
+// CategoryTree retrieves the root categories of the given site, returning a tree
+// which will load the rest of the nodes on demand
+func (ml *MeLi) CategoryTree(siteId SiteId) (*CategoryTree, error) {
+	roots, err := ml.ListCategories(siteId)
+	if err != nil {
+		return nil, err
+	}
+	return &CategoryTree{
+		SiteId:     siteId,
+		Roots:      roots,
+		Categories: make(map[CategoryId]*Category),
+		ml:         ml,
+	}, nil
+}
+
+// ReadCategoryTree decodes a tree previously dumped with Dump.
+// The client is used to load the nodes that weren't in the dump (it can be nil for pure offline usage)
+func (ml *MeLi) ReadCategoryTree(r io.Reader) (*CategoryTree, error) {
+	tree := &CategoryTree{}
+	err := json.NewDecoder(r).Decode(tree)
+	if err != nil {
+		return nil, err
+	}
+	if tree.Categories == nil {
+		tree.Categories = make(map[CategoryId]*Category)
+	}
+	tree.ml = ml
+	return tree, nil
+}
+
+// ReadCategoryTreeFile is the same as ReadCategoryTree but reading from the given filename
+func (ml *MeLi) ReadCategoryTreeFile(filename string) (*CategoryTree, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ml.ReadCategoryTree(f)
+}
+
+// Category retrieves the details of the given category, fetching it in case it wasn't loaded yet
+func (tree *CategoryTree) Category(catId CategoryId) (*Category, error) {
+	if catId == "" {
+		return nil, ErrNilCategoryId
+	}
+	tree.lock.Lock()
+	cat, ok := tree.Categories[catId]
+	tree.lock.Unlock()
+	if ok {
+		return cat, nil
+	}
+	if tree.ml == nil {
+		return nil, ErrCategoryNotFound
+	}
+	cat, err := tree.ml.GetCategory(catId)
+	if err != nil {
+		return nil, err
+	}
+	tree.lock.Lock()
+	tree.Categories[catId] = cat
+	tree.lock.Unlock()
+	return cat, nil
+}
+
+// Children retrieves the direct children of the given category.
+// An empty catId retrieves the roots of the tree
+func (tree *CategoryTree) Children(catId CategoryId) ([]*Category, error) {
+	if catId == "" {
+		return tree.Roots, nil
+	}
+	cat, err := tree.Category(catId)
+	if err != nil {
+		return nil, err
+	}
+	return cat.ChildrenCategories, nil
+}
+
+// Walk traverses the tree depth-first starting by its roots, loading each node it visits.
+// The given fn is called with the details of every category; in case it errs, the walk stops and returns that err
+func (tree *CategoryTree) Walk(fn func(cat *Category) error) error {
+	for _, root := range tree.Roots {
+		err := tree.walk(root.Id, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tree *CategoryTree) walk(catId CategoryId, fn func(cat *Category) error) error {
+	cat, err := tree.Category(catId)
+	if err != nil {
+		return err
+	}
+	err = fn(cat)
+	if err != nil {
+		return err
+	}
+	for _, child := range cat.ChildrenCategories {
+		err = tree.walk(child.Id, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load fetches every node of the tree. Useful before dumping it
+func (tree *CategoryTree) Load() error {
+	return tree.Walk(func(*Category) error { return nil })
+}
+
+// Leaves retrieves every leaf category of the tree, loading it entirely
+func (tree *CategoryTree) Leaves() ([]*Category, error) {
+	var leaves []*Category
+	err := tree.Walk(func(cat *Category) error {
+		if cat.IsLeaf() {
+			leaves = append(leaves, cat)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+// FindByPath retrieves the category which is reached by the given names, starting from a root.
+// The names are compared case-insensitively, only loading the nodes of the path
+func (tree *CategoryTree) FindByPath(names ...string) (*Category, error) {
+	if len(names) == 0 {
+		return nil, ErrNilCategoryPath
+	}
+	var catId CategoryId
+	for _, name := range names {
+		children, err := tree.Children(catId)
+		if err != nil {
+			return nil, err
+		}
+		catId = ""
+		for _, child := range children {
+			if strings.EqualFold(strings.TrimSpace(child.Name), strings.TrimSpace(name)) {
+				catId = child.Id
+				break
+			}
+		}
+		if catId == "" {
+			return nil, ErrCategoryNotFound
+		}
+	}
+	return tree.Category(catId)
+}
+
+// Dump encodes the loaded nodes of the tree, so it can be later read offline with ReadCategoryTree
+func (tree *CategoryTree) Dump(w io.Writer) error {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	return json.NewEncoder(w).Encode(tree)
+}
+
+// DumpFile fully loads the tree and dumps it into the given filename
+func (tree *CategoryTree) DumpFile(filename string) error {
+	err := tree.Load()
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = tree.Dump(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,134 @@
+package meli
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sebach1/httpstub"
+)
+
+func categoryTreeStubs() []*httpstub.Stub {
+	return []*httpstub.Stub{
+		{Status: 200,
+			URL:  "/sites/MLA/categories",
+			Body: []*Category{{Id: "MLA1", Name: "Foo"}, {Id: "MLA2", Name: "Bar"}},
+		},
+		{Status: 200,
+			URL: "/categories/MLA1",
+			Body: &Category{Id: "MLA1", Name: "Foo",
+				ChildrenCategories: []*Category{{Id: "MLA11", Name: "Baz"}},
+			},
+		},
+		{Status: 200,
+			URL: "/categories/MLA11",
+			Body: &Category{Id: "MLA11", Name: "Baz",
+				PathFromRoot: []*Category{{Id: "MLA1", Name: "Foo"}, {Id: "MLA11", Name: "Baz"}},
+			},
+		},
+		{Status: 200,
+			URL:  "/categories/MLA2",
+			Body: &Category{Id: "MLA2", Name: "Bar"},
+		},
+	}
+}
+
+func TestCategoryTree_FindByPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		path    []string
+		want    *Category
+		wantErr error
+	}{
+		{
+			name:    "NIL path",
+			wantErr: ErrNilCategoryPath,
+		},
+		{
+			name:    "path does NOT EXISTS",
+			path:    []string{"Foo", "Quux"},
+			wantErr: ErrCategoryNotFound,
+		},
+		{
+			name: "path to a ROOT",
+			path: []string{"bar"},
+			want: &Category{Id: "MLA2", Name: "Bar"},
+		},
+		{
+			name: "path to a LEAF",
+			path: []string{"foo", " BAZ "},
+			want: &Category{Id: "MLA11", Name: "Baz",
+				PathFromRoot: []*Category{{Id: "MLA1", Name: "Foo"}, {Id: "MLA11", Name: "Baz"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: categoryTreeStubs(), Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			tree, err := ml.CategoryTree("MLA")
+			if err != nil {
+				t.Fatalf("MeLi.CategoryTree() error = %v", err)
+			}
+			got, err := tree.FindByPath(tt.path...)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("CategoryTree.FindByPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CategoryTree.FindByPath() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestCategoryTree_Dump(t *testing.T) {
+	t.Parallel()
+	ml := &MeLi{}
+	stubber := httpstub.Stubber{Stubs: categoryTreeStubs(), Client: ml}
+	cleanup := stubber.Serve(t)
+	defer cleanup()
+
+	tree, err := ml.CategoryTree("MLA")
+	if err != nil {
+		t.Fatalf("MeLi.CategoryTree() error = %v", err)
+	}
+	leaves, err := tree.Leaves()
+	if err != nil {
+		t.Fatalf("CategoryTree.Leaves() error = %v", err)
+	}
+	var leavesIds []CategoryId
+	for _, leaf := range leaves {
+		leavesIds = append(leavesIds, leaf.Id)
+	}
+	if diff := cmp.Diff([]CategoryId{"MLA11", "MLA2"}, leavesIds); diff != "" {
+		t.Errorf("CategoryTree.Leaves() mismatch (-want +got): %s", diff)
+	}
+
+	buf := &bytes.Buffer{}
+	err = tree.Dump(buf)
+	if err != nil {
+		t.Fatalf("CategoryTree.Dump() error = %v", err)
+	}
+	offline, err := (*MeLi)(nil).ReadCategoryTree(buf)
+	if err != nil {
+		t.Fatalf("MeLi.ReadCategoryTree() error = %v", err)
+	}
+	if diff := cmp.Diff(tree, offline, cmpopts.IgnoreUnexported(CategoryTree{})); diff != "" {
+		t.Errorf("MeLi.ReadCategoryTree() mismatch (-want +got): %s", diff)
+	}
+	got, err := offline.FindByPath("Foo", "Baz")
+	if err != nil {
+		t.Fatalf("CategoryTree.FindByPath() offline error = %v", err)
+	}
+	if got.PathString(" > ") != "Foo > Baz" {
+		t.Errorf("Category.PathString() = %v, want %v", got.PathString(" > "), "Foo > Baz")
+	}
+}
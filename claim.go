@@ -0,0 +1,327 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ClaimId int64
+
+// Claim is a complaint of a buyer about a purchase, which can escalate onto a mediation (dispute) of MeLi
+type Claim struct {
+	Id          ClaimId      `json:"id"`
+	ResourceId  int64        `json:"resource_id,omitempty"` // e.g. the order id
+	Resource    string       `json:"resource,omitempty"`    // e.g. order
+	Status      ClaimStatus  `json:"status,omitempty"`
+	Type        string       `json:"type,omitempty"` // e.g. mediations, return, cancel_purchase
+	Stage       ClaimStage   `json:"stage,omitempty"`
+	ParentId    ClaimId      `json:"parent_id,omitempty"`
+	ReasonId    string       `json:"reason_id,omitempty"`
+	Fulfilled   bool         `json:"fulfilled,omitempty"`
+	SiteId      SiteId       `json:"site_id,omitempty"`
+	Players     []*ClaimUser `json:"players,omitempty"`
+	Resolution  *Resolution  `json:"resolution,omitempty"`
+	DateCreated time.Time    `json:"date_created,omitempty"`
+	LastUpdated time.Time    `json:"last_updated,omitempty"`
+}
+
+type ClaimStatus string
+
+const (
+	ClaimOpened ClaimStatus = "opened"
+	ClaimClosed ClaimStatus = "closed"
+)
+
+type ClaimStage string
+
+const (
+	ClaimStageClaim     ClaimStage = "claim"
+	ClaimStageDispute   ClaimStage = "dispute"
+	ClaimStageRecontact ClaimStage = "recontact"
+	ClaimStageNone      ClaimStage = "none"
+)
+
+type ClaimRole string
+
+const (
+	ClaimComplainant ClaimRole = "complainant"
+	ClaimRespondent  ClaimRole = "respondent"
+	ClaimMediator    ClaimRole = "mediator"
+)
+
+// ClaimUser is a party of the claim, with the actions it can perform on its current stage
+type ClaimUser struct {
+	Role             ClaimRole      `json:"role"`
+	Type             string         `json:"type,omitempty"` // e.g. buyer, seller, internal
+	UserId           int            `json:"user_id"`
+	AvailableActions []*ClaimAction `json:"available_actions,omitempty"`
+}
+
+type ClaimAction struct {
+	Action    string     `json:"action"` // e.g. send_message_to_complainant, refund, open_dispute
+	Mandatory bool       `json:"mandatory"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+}
+
+type Resolution struct {
+	Reason      string    `json:"reason,omitempty"`
+	Benefited   []string  `json:"benefited,omitempty"`
+	ClosedBy    string    `json:"closed_by,omitempty"`
+	DateCreated time.Time `json:"date_created,omitempty"`
+}
+
+// Actions retrieves the actions the party of the given role can perform on the claim
+func (claim *Claim) Actions(role ClaimRole) []*ClaimAction {
+	for _, player := range claim.Players {
+		if player.Role == role {
+			return player.AvailableActions
+		}
+	}
+	return nil
+}
+
+// ClaimSearch filters the claims the authenticated user takes part on
+type ClaimSearch struct {
+	Status     ClaimStatus
+	Stage      ClaimStage
+	Type       string
+	Role       ClaimRole // The role of the authenticated user on the claim
+	ResourceId int64
+	Offset     int
+	Limit      int
+}
+
+type ClaimEdge struct {
+	Paging Paging   `json:"paging"`
+	Data   []*Claim `json:"data"`
+}
+
+// SearchClaims retrieves a page of the claims matching the given search
+func (ml *MeLi) SearchClaims(search *ClaimSearch) (*ClaimEdge, error) {
+	if search == nil {
+		search = &ClaimSearch{}
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	if search.Status != "" {
+		params.Set("status", string(search.Status))
+	}
+	if search.Stage != "" {
+		params.Set("stage", string(search.Stage))
+	}
+	if search.Type != "" {
+		params.Set("type", search.Type)
+	}
+	if search.Role != "" {
+		params.Set("players.role", string(search.Role))
+	}
+	if search.ResourceId != 0 {
+		params.Set("resource_id", strconv.FormatInt(search.ResourceId, 10))
+	}
+	if search.Offset > 0 {
+		params.Set("offset", strconv.Itoa(search.Offset))
+	}
+	if search.Limit > 0 {
+		params.Set("limit", strconv.Itoa(search.Limit))
+	}
+	URL, err := ml.RouteTo("/post-purchase/v1/claims/search", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &ClaimEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+func (ml *MeLi) GetClaim(id ClaimId) (*Claim, error) {
+	if id == 0 {
+		return nil, ErrNilClaimId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/post-purchase/v1/claims/%v", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	claim := &Claim{}
+	err = json.NewDecoder(resp.Body).Decode(claim)
+	if err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// ClaimActions retrieves the actions the party of the given role can currently perform on the claim
+func (ml *MeLi) ClaimActions(id ClaimId, role ClaimRole) ([]*ClaimAction, error) {
+	if role == "" {
+		return nil, ErrNilClaimRole
+	}
+	claim, err := ml.GetClaim(id)
+	if err != nil {
+		return nil, err
+	}
+	return claim.Actions(role), nil
+}
+
+// ClaimMessage is a message between the parties of a claim
+type ClaimMessage struct {
+	SenderRole   ClaimRole            `json:"sender_role"`
+	ReceiverRole ClaimRole            `json:"receiver_role"`
+	Message      string               `json:"message"`
+	Stage        ClaimStage           `json:"stage,omitempty"`
+	Status       string               `json:"status,omitempty"`
+	Attachments  []*MessageAttachment `json:"attachments,omitempty"`
+	DateCreated  time.Time            `json:"date_created,omitempty"`
+}
+
+func (ml *MeLi) ClaimMessages(id ClaimId) ([]*ClaimMessage, error) {
+	if id == 0 {
+		return nil, ErrNilClaimId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/post-purchase/v1/claims/%v/messages", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	var msgs []*ClaimMessage
+	err = json.NewDecoder(resp.Body).Decode(&msgs)
+	if err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+type claimMessageRequest struct {
+	ReceiverRole ClaimRole      `json:"receiver_role"`
+	Message      string         `json:"message"`
+	Attachments  []AttachmentId `json:"attachments,omitempty"`
+}
+
+// SendClaimMessage sends a message to the party of the given role (e.g. the complainant, or the mediator
+// once disputed), attaching the evidence previously uploaded by UploadClaimEvidence
+func (ml *MeLi) SendClaimMessage(id ClaimId, receiver ClaimRole, text string, attachments ...AttachmentId) error {
+	if id == 0 {
+		return ErrNilClaimId
+	}
+	if receiver == "" {
+		return ErrNilClaimRole
+	}
+	if text == "" {
+		return ErrNilMessageText
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return err
+	}
+	URL, err := ml.RouteTo("/post-purchase/v1/claims/%v/actions/send-message", params, id)
+	if err != nil {
+		return err
+	}
+	reqBody, err := json.Marshal(&claimMessageRequest{ReceiverRole: receiver, Message: text, Attachments: attachments})
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Post(URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
+// UploadClaimEvidence uploads the file read from r onto the claim, retrieving the id to attach it to a message
+func (ml *MeLi) UploadClaimEvidence(id ClaimId, filename string, r io.Reader) (AttachmentId, error) {
+	if id == 0 {
+		return "", ErrNilClaimId
+	}
+	if filename == "" || r == nil {
+		return "", ErrNilAttachment
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return "", err
+	}
+	URL, err := ml.RouteTo("/post-purchase/v1/claims/%v/attachments", params, id)
+	if err != nil {
+		return "", err
+	}
+	resp, err := ml.postFile(URL, "file", filename, r)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", errFromReader(resp.Body)
+	}
+	uploaded := &struct {
+		Filename AttachmentId `json:"filename"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(uploaded)
+	if err != nil {
+		return "", err
+	}
+	return uploaded.Filename, nil
+}
+
+func (ml *MeLi) ProcessClaimWebhook(wh *Webhook) (*Claim, error) {
+	id, err := wh.resourceIdOf(ResourceClaims)
+	if err != nil {
+		return nil, err
+	}
+	claimId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidResource
+	}
+	return ml.GetClaim(ClaimId(claimId))
+}
+
+// ClaimWebhookFunc adapts fn to be registered for the claims topic, retrieving the notified claim for it
+func (ml *MeLi) ClaimWebhookFunc(fn func(claim *Claim) error) WebhookFunc {
+	return func(wh *Webhook) error {
+		claim, err := ml.ProcessClaimWebhook(wh)
+		if err != nil {
+			return err
+		}
+		return fn(claim)
+	}
+}
@@ -0,0 +1,370 @@
+package meli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_SearchClaims(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		search   *ClaimSearch
+		stub     *httpstub.Stub
+		wantEdge *ClaimEdge
+		wantErr  error
+	}{
+		{
+			name:   "REMOTE returns an ERR",
+			search: &ClaimSearch{Status: ClaimOpened},
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/post-purchase/v1/claims/search",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"}, "status": []string{"opened"},
+				}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			search: &ClaimSearch{Status: ClaimOpened, Stage: ClaimStageDispute, Role: ClaimRespondent, Limit: 10},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/post-purchase/v1/claims/search",
+				Body: &ClaimEdge{Paging: Paging{Total: 1}, Data: []*Claim{
+					{Id: 1, ResourceId: 2, Resource: "order", Status: ClaimOpened, Stage: ClaimStageDispute},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"},
+					"status":       []string{"opened"},
+					"stage":        []string{"dispute"},
+					"players.role": []string{"respondent"},
+					"limit":        []string{"10"},
+				}},
+			},
+			wantEdge: &ClaimEdge{Paging: Paging{Total: 1}, Data: []*Claim{
+				{Id: 1, ResourceId: 2, Resource: "order", Status: ClaimOpened, Stage: ClaimStageDispute},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotEdge, err := ml.SearchClaims(tt.search)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.SearchClaims() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantEdge, gotEdge); diff != "" {
+				t.Errorf("MeLi.SearchClaims() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_SendClaimMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		id          ClaimId
+		receiver    ClaimRole
+		text        string
+		attachments []AttachmentId
+		stub        *httpstub.Stub
+		wantErr     error
+	}{
+		{
+			name:     "NIL CLAIM ID",
+			receiver: ClaimComplainant,
+			text:     "bar",
+			wantErr:  ErrNilClaimId,
+		},
+		{
+			name:    "NIL RECEIVER",
+			id:      1,
+			text:    "bar",
+			wantErr: ErrNilClaimRole,
+		},
+		{
+			name:     "NIL TEXT",
+			id:       1,
+			receiver: ClaimComplainant,
+			wantErr:  ErrNilMessageText,
+		},
+		{
+			name:        "REMOTE returns CORRECTly",
+			id:          1,
+			receiver:    ClaimMediator,
+			text:        "bar",
+			attachments: []AttachmentId{"baz.pdf"},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/post-purchase/v1/claims/1/actions/send-message",
+				Receive: httpstub.Receive{
+					Params: url.Values{"access_token": []string{"foo"}},
+					Body:   []byte(`{"receiver_role":"mediator","message":"bar","attachments":["baz.pdf"]}`),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			err := ml.SendClaimMessage(tt.id, tt.receiver, tt.text, tt.attachments...)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.SendClaimMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMeLi_GetClaim(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		id        ClaimId
+		stub      *httpstub.Stub
+		wantClaim *Claim
+		wantErr   error
+	}{
+		{
+			name:    "NIL CLAIM ID",
+			wantErr: ErrNilClaimId,
+		},
+		{
+			name: "REMOTE returns an ERR",
+			id:   1,
+			stub: &httpstub.Stub{Status: 404,
+				URL:     "/post-purchase/v1/claims/1",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name: "REMOTE returns CORRECTly",
+			id:   1,
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/post-purchase/v1/claims/1",
+				Body: &Claim{Id: 1, ResourceId: 2, Resource: "order", Status: ClaimOpened, Players: []*ClaimUser{
+					{Role: ClaimRespondent, UserId: 3, AvailableActions: []*ClaimAction{{Action: "refund"}}},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantClaim: &Claim{Id: 1, ResourceId: 2, Resource: "order", Status: ClaimOpened, Players: []*ClaimUser{
+				{Role: ClaimRespondent, UserId: 3, AvailableActions: []*ClaimAction{{Action: "refund"}}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotClaim, err := ml.GetClaim(tt.id)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.GetClaim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantClaim, gotClaim); diff != "" {
+				t.Errorf("MeLi.GetClaim() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_ClaimActions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		id          ClaimId
+		role        ClaimRole
+		stub        *httpstub.Stub
+		wantActions []*ClaimAction
+		wantErr     error
+	}{
+		{
+			name:    "NIL ROLE",
+			id:      1,
+			wantErr: ErrNilClaimRole,
+		},
+		{
+			name:    "NIL CLAIM ID",
+			role:    ClaimRespondent,
+			wantErr: ErrNilClaimId,
+		},
+		{
+			name: "REMOTE returns CORRECTly",
+			id:   1,
+			role: ClaimRespondent,
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/post-purchase/v1/claims/1",
+				Body: &Claim{Id: 1, Players: []*ClaimUser{
+					{Role: ClaimComplainant, UserId: 2, AvailableActions: []*ClaimAction{{Action: "open_dispute"}}},
+					{Role: ClaimRespondent, UserId: 3, AvailableActions: []*ClaimAction{{Action: "refund", Mandatory: true}}},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantActions: []*ClaimAction{{Action: "refund", Mandatory: true}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotActions, err := ml.ClaimActions(tt.id, tt.role)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ClaimActions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantActions, gotActions); diff != "" {
+				t.Errorf("MeLi.ClaimActions() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_ClaimMessages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       ClaimId
+		stub     *httpstub.Stub
+		wantMsgs []*ClaimMessage
+		wantErr  error
+	}{
+		{
+			name:    "NIL CLAIM ID",
+			wantErr: ErrNilClaimId,
+		},
+		{
+			name: "REMOTE returns an ERR",
+			id:   1,
+			stub: &httpstub.Stub{Status: 404,
+				URL:     "/post-purchase/v1/claims/1/messages",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name: "REMOTE returns CORRECTly",
+			id:   1,
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/post-purchase/v1/claims/1/messages",
+				Body: []*ClaimMessage{
+					{SenderRole: ClaimComplainant, ReceiverRole: ClaimRespondent, Message: "bar", Stage: ClaimStageClaim},
+					{SenderRole: ClaimRespondent, ReceiverRole: ClaimComplainant, Message: "baz", Stage: ClaimStageClaim},
+				},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantMsgs: []*ClaimMessage{
+				{SenderRole: ClaimComplainant, ReceiverRole: ClaimRespondent, Message: "bar", Stage: ClaimStageClaim},
+				{SenderRole: ClaimRespondent, ReceiverRole: ClaimComplainant, Message: "baz", Stage: ClaimStageClaim},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotMsgs, err := ml.ClaimMessages(tt.id)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ClaimMessages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantMsgs, gotMsgs); diff != "" {
+				t.Errorf("MeLi.ClaimMessages() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_UploadClaimEvidence(t *testing.T) {
+	t.Parallel()
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	var reqs int
+	ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		reqs++
+		if req.URL.Path != "/post-purchase/v1/claims/1/attachments" || req.URL.Query().Get("access_token") != "foo" {
+			t.Errorf("MeLi.UploadClaimEvidence() requested %v", req.URL)
+		}
+		assertMultipartFile(t, req, "file", "baz.pdf", "qux")
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{},
+			Body: ioutil.NopCloser(strings.NewReader(`{"filename":"123_baz.pdf"}`)),
+		}, nil
+	})})
+
+	if _, err := ml.UploadClaimEvidence(0, "baz.pdf", strings.NewReader("qux")); err != ErrNilClaimId {
+		t.Errorf("MeLi.UploadClaimEvidence() error = %v, wantErr %v", err, ErrNilClaimId)
+	}
+	gotId, err := ml.UploadClaimEvidence(1, "baz.pdf", strings.NewReader("qux"))
+	if err != nil {
+		t.Errorf("MeLi.UploadClaimEvidence() error = %v, wantErr %v", err, nil)
+	}
+	if gotId != "123_baz.pdf" {
+		t.Errorf("MeLi.UploadClaimEvidence() = %v, want %v", gotId, "123_baz.pdf")
+	}
+	if reqs != 1 {
+		t.Errorf("MeLi.UploadClaimEvidence() performed %v requests, want %v", reqs, 1)
+	}
+}
+
+func TestMeLi_ProcessClaimWebhook(t *testing.T) {
+	t.Parallel()
+	claim := &Claim{Id: 5000001, Status: ClaimOpened, Players: []*ClaimUser{
+		{Role: ClaimComplainant, UserId: 3},
+		{Role: ClaimRespondent, UserId: 1, AvailableActions: []*ClaimAction{{Action: "refund", Mandatory: true}}},
+	}}
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{
+		{Status: 200, URL: "/post-purchase/v1/claims/5000001", Body: claim,
+			Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+		},
+	}, Client: ml}
+	cleanup := stubber.Serve(t)
+	defer cleanup()
+
+	_, err := ml.ProcessClaimWebhook(&Webhook{Topic: TopicClaims, Resource: "/post-purchase/v1/claims/abc"})
+	if err != ErrInvalidResource {
+		t.Errorf("MeLi.ProcessClaimWebhook() error = %v, wantErr %v", err, ErrInvalidResource)
+	}
+	gotClaim, err := ml.ProcessClaimWebhook(&Webhook{Topic: TopicClaims, Resource: "/post-purchase/v1/claims/5000001"})
+	if err != nil {
+		t.Errorf("MeLi.ProcessClaimWebhook() error = %v, wantErr %v", err, nil)
+	}
+	if diff := cmp.Diff(claim, gotClaim); diff != "" {
+		t.Errorf("MeLi.ProcessClaimWebhook() mismatch (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]*ClaimAction{{Action: "refund", Mandatory: true}}, gotClaim.Actions(ClaimRespondent)); diff != "" {
+		t.Errorf("Claim.Actions() mismatch (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,195 @@
+package meli
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// maxClassifyChunk is the max quantity of titles sent on each request of ClassifyAll
+const maxClassifyChunk = 100
+
+// Classification pairs each title with its category, or with the err produced while classifying it
+type Classification struct {
+	Title    string    `json:"title,omitempty"`
+	Category *Category `json:"category,omitempty"`
+	Err      error     `json:"-"`
+}
+
+type ClassifyOptions struct {
+	// ChunkSize is the quantity of titles of each request (defaults to the max allowed)
+	ChunkSize int
+	// Parallelism is the max quantity of requests performed at once (defaults to 4)
+	Parallelism int
+	// Checkpoint stores the classified titles, so a later run can resume from it
+	Checkpoint ClassifyCheckpoint
+}
+
+// ClassifyCheckpoint is the storage of the progress of ClassifyAll
+type ClassifyCheckpoint interface {
+	// Load retrieves the classifications already done
+	Load() ([]*Classification, error)
+	// Save is called with the successful classifications of each chunk
+	Save(cls []*Classification) error
+}
+
+// ClassifyAll classifies any quantity of titles by splitting them onto chunks which are classified in parallel.
+// Its result is aligned with the given titles; a failed chunk doesn't fail the rest, but sets the Err of its classifications
+func (ml *MeLi) ClassifyAll(titles []string, siteId SiteId, opts *ClassifyOptions) ([]*Classification, error) {
+	if opts == nil {
+		opts = &ClassifyOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 || chunkSize > maxClassifyChunk {
+		chunkSize = maxClassifyChunk
+	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = 4
+	}
+
+	done := make(map[string]*Category)
+	if opts.Checkpoint != nil {
+		cls, err := opts.Checkpoint.Load()
+		if err != nil {
+			return nil, err
+		}
+		for _, cl := range cls {
+			done[cl.Title] = cl.Category
+		}
+	}
+	results := make([]*Classification, len(titles))
+	var pending []int
+	for i, title := range titles {
+		if cat, ok := done[title]; ok {
+			results[i] = &Classification{Title: title, Category: cat}
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var checkpointErr error
+	sem := make(chan struct{}, parallelism)
+	for _, chunk := range chunkIdxs(pending, chunkSize) {
+		chunk := chunk
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			cls := ml.classifyChunk(titles, chunk, siteId)
+			for i, idx := range chunk {
+				results[idx] = cls[i]
+			}
+			if opts.Checkpoint == nil {
+				return
+			}
+			var succeeded []*Classification
+			for _, cl := range cls {
+				if cl.Err == nil {
+					succeeded = append(succeeded, cl)
+				}
+			}
+			if err := opts.Checkpoint.Save(succeeded); err != nil {
+				lock.Lock()
+				checkpointErr = err
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results, checkpointErr
+}
+
+func (ml *MeLi) classifyChunk(titles []string, chunk []int, siteId SiteId) []*Classification {
+	var chunkTitles []string
+	cls := make([]*Classification, len(chunk))
+	for i, idx := range chunk {
+		chunkTitles = append(chunkTitles, titles[idx])
+		cls[i] = &Classification{Title: titles[idx]}
+	}
+	cats, err := ml.ClassifyBatch(chunkTitles, siteId)
+	if err == nil && len(cats) != len(chunkTitles) {
+		err = ErrRemoteInconsistency
+	}
+	for i, cl := range cls {
+		switch {
+		case err != nil:
+			cl.Err = err
+		case cats[i] == nil || cats[i].Id == "":
+			cl.Err = ErrNilCategory
+		default:
+			cl.Category = cats[i]
+		}
+	}
+	return cls
+}
+
+func chunkIdxs(idxs []int, sz int) (chunked [][]int) {
+	for i := 0; i < len(idxs); i += sz {
+		end := i + sz
+		if end > len(idxs) {
+			end = len(idxs)
+		}
+		chunked = append(chunked, idxs[i:end])
+	}
+	return
+}
+
+// FileCheckpoint is a ClassifyCheckpoint which appends each saved classification as a JSON line of its file
+type FileCheckpoint struct {
+	filename string
+	lock     sync.Mutex
+}
+
+func NewFileCheckpoint(filename string) *FileCheckpoint {
+	return &FileCheckpoint{filename: filename}
+}
+
+func (c *FileCheckpoint) Load() ([]*Classification, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	f, err := os.Open(c.filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var cls []*Classification
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		cl := &Classification{}
+		err = json.Unmarshal(scanner.Bytes(), cl)
+		if err != nil {
+			continue // A line can be partially written in case of crashing; skip it to resume the rest
+		}
+		cls = append(cls, cl)
+	}
+	return cls, scanner.Err()
+}
+
+func (c *FileCheckpoint) Save(cls []*Classification) error {
+	if len(cls) == 0 {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	f, err := os.OpenFile(c.filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, cl := range cls {
+		err = enc.Encode(cl)
+		if err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
@@ -0,0 +1,227 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_ClassifyAll(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		done    []*Classification
+		stub    *httpstub.Stub
+		want    []*Classification
+		wantErr error
+	}{
+		{
+			name: "RESUMES from the checkpoint",
+			done: []*Classification{{Title: "a", Category: &Category{Id: "foo"}}},
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/sites/MLA/categories/category_predictor/predict",
+				Body: []*Category{{Id: "bar"}, {Id: "baz"}},
+				Receive: httpstub.Receive{
+					Body: JSONMarshal(t, []map[string]string{{"title": "b"}, {"title": "c"}}),
+				},
+			},
+			want: []*Classification{
+				{Title: "a", Category: &Category{Id: "foo"}},
+				{Title: "b", Category: &Category{Id: "bar"}},
+				{Title: "c", Category: &Category{Id: "baz"}},
+			},
+		},
+		{
+			name: "REMOTE response is MISALIGNED",
+			stub: &httpstub.Stub{Status: 200,
+				URL:    "/sites/MLA/categories/category_predictor/predict",
+				Body:   []*Category{{Id: "bar"}},
+				Config: httpstub.StubConfig{DontAssertReceive: true},
+			},
+			want: []*Classification{
+				{Title: "a", Err: ErrRemoteInconsistency},
+				{Title: "b", Err: ErrRemoteInconsistency},
+				{Title: "c", Err: ErrRemoteInconsistency},
+			},
+		},
+		{
+			name: "REMOTE returns an ERR",
+			stub: &httpstub.Stub{Status: 400,
+				URL:    "/sites/MLA/categories/category_predictor/predict",
+				Body:   svErrFooBar,
+				Config: httpstub.StubConfig{DontAssertReceive: true},
+			},
+			want: []*Classification{
+				{Title: "a", Err: svErrFooBar},
+				{Title: "b", Err: svErrFooBar},
+				{Title: "c", Err: svErrFooBar},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			dir, err := ioutil.TempDir("", "meli")
+			if err != nil {
+				t.Fatalf("couldn't create temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			checkpoint := NewFileCheckpoint(filepath.Join(dir, "checkpoint.jsonl"))
+			err = checkpoint.Save(tt.done)
+			if err != nil {
+				t.Fatalf("FileCheckpoint.Save() error = %v", err)
+			}
+
+			got, err := ml.ClassifyAll([]string{"a", "b", "c"}, "MLA", &ClassifyOptions{ChunkSize: 3, Checkpoint: checkpoint})
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ClassifyAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			errComparer := cmp.Comparer(func(x, y error) bool { return fmt.Sprintf("%v", x) == fmt.Sprintf("%v", y) })
+			if diff := cmp.Diff(tt.want, got, errComparer); diff != "" {
+				t.Errorf("MeLi.ClassifyAll() mismatch (-want +got): %s", diff)
+			}
+
+			saved, err := checkpoint.Load()
+			if err != nil {
+				t.Fatalf("FileCheckpoint.Load() error = %v", err)
+			}
+			var wantSaved []*Classification
+			for _, cl := range tt.want {
+				if cl.Err == nil {
+					wantSaved = append(wantSaved, cl)
+				}
+			}
+			if diff := cmp.Diff(wantSaved, saved, errComparer); diff != "" {
+				t.Errorf("FileCheckpoint.Load() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_ClassifyAll_Chunks(t *testing.T) {
+	t.Parallel()
+	const parallelism = 2
+	var titles []string
+	for i := 0; i < 250; i++ {
+		titles = append(titles, fmt.Sprintf("t%d", i))
+	}
+	var lock sync.Mutex
+	var chunks [][]string
+	var inFlight, maxInFlight int32
+	ml := &MeLi{}
+	failing := "t150"
+	ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		var reqTitles []map[string]string
+		err := json.NewDecoder(req.Body).Decode(&reqTitles)
+		if err != nil {
+			return nil, err
+		}
+		var chunk []string
+		var cats []*Category
+		status := http.StatusOK
+		for _, title := range reqTitles {
+			chunk = append(chunk, title["title"])
+			cats = append(cats, &Category{Id: CategoryId("cat-" + title["title"])})
+			if title["title"] == failing {
+				status = http.StatusInternalServerError
+			}
+		}
+		lock.Lock()
+		chunks = append(chunks, chunk)
+		if cur > maxInFlight {
+			maxInFlight = cur
+		}
+		lock.Unlock()
+		var body []byte
+		if status == http.StatusOK {
+			body = JSONMarshal(t, cats)
+		} else {
+			body = JSONMarshal(t, svErrFooBar)
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+	})})
+
+	dir, err := ioutil.TempDir("", "meli")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	checkpoint := NewFileCheckpoint(filepath.Join(dir, "checkpoint.jsonl"))
+	var done []*Classification
+	for _, title := range titles[:30] {
+		done = append(done, &Classification{Title: title, Category: &Category{Id: CategoryId("cat-" + title)}})
+	}
+	err = checkpoint.Save(done)
+	if err != nil {
+		t.Fatalf("FileCheckpoint.Save() error = %v", err)
+	}
+
+	opts := &ClassifyOptions{Parallelism: parallelism, Checkpoint: checkpoint}
+	got, err := ml.ClassifyAll(titles, "MLA", opts)
+	if err != nil {
+		t.Fatalf("MeLi.ClassifyAll() error = %v", err)
+	}
+	// The 220 pending titles are split onto chunks of the max allowed
+	var sizes []int
+	for _, chunk := range chunks {
+		sizes = append(sizes, len(chunk))
+	}
+	sort.Ints(sizes)
+	if diff := cmp.Diff([]int{20, 100, 100}, sizes); diff != "" {
+		t.Errorf("MeLi.ClassifyAll() chunk sizes mismatch (-want +got): %s", diff)
+	}
+	if maxInFlight > parallelism {
+		t.Errorf("MeLi.ClassifyAll() performed %v requests at once, want up to %v", maxInFlight, parallelism)
+	}
+	var failed []string
+	for i, cl := range got {
+		if cl.Title != titles[i] {
+			t.Fatalf("MeLi.ClassifyAll() result %v = %v, NOT ALIGNED with title %v", i, cl.Title, titles[i])
+		}
+		if cl.Err != nil {
+			failed = append(failed, cl.Title)
+			continue
+		}
+		if cl.Category == nil || cl.Category.Id != CategoryId("cat-"+cl.Title) {
+			t.Errorf("MeLi.ClassifyAll() category of %v = %v, want %v", cl.Title, cl.Category, "cat-"+cl.Title)
+		}
+	}
+	// The failed chunk is the one of t150 (t130 to t229)
+	if len(failed) != 100 || failed[0] != "t130" || failed[99] != "t229" {
+		t.Errorf("MeLi.ClassifyAll() failed %v titles (from %v), want the 100 of the t130 chunk", len(failed), failed)
+	}
+
+	// A later run resumes only the failed titles
+	chunks, failing = nil, ""
+	got, err = ml.ClassifyAll(titles, "MLA", opts)
+	if err != nil {
+		t.Fatalf("MeLi.ClassifyAll() error = %v", err)
+	}
+	if len(chunks) != 1 || len(chunks[0]) != 100 || chunks[0][0] != "t130" {
+		t.Errorf("MeLi.ClassifyAll() resumed chunks = %v, want the failed one", chunks)
+	}
+	for _, cl := range got {
+		if cl.Err != nil {
+			t.Errorf("MeLi.ClassifyAll() resumed %v with err %v", cl.Title, cl.Err)
+		}
+	}
+}
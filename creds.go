@@ -11,6 +11,9 @@ type creds struct {
 	Refresh       refreshToken
 	ApplicationId applicationId
 	Secret        token
+
+	UserId int   // The user who granted the access token
+	me     *User // Cached profile of the user
 }
 
 func (c *creds) validateClient() error {
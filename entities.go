@@ -38,13 +38,8 @@ type SellerAddress struct {
 	Id        int     `json:"id,omitempty"`
 }
 
-type Shipping struct {
-	Mode         string        `json:"mode,omitempty"`
-	Methods      []interface{} `json:"methods,omitempty"`
-	Tags         []string      `json:"tags,omitempty"`
-	Dimensions   interface{}   `json:"dimensions,omitempty"`
-	LocalPickUp  bool          `json:"local_pick_up,omitempty"`
-	FreeShipping bool          `json:"free_shipping,omitempty"`
-	LogisticType string        `json:"logistic_type,omitempty"`
-	StorePickUp  bool          `json:"store_pick_up,omitempty"`
+type Paging struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	Total  int `json:"total"`
 }
@@ -28,6 +28,20 @@ var (
 	ErrInvalidCategoryId = errors.New("the given CATEGORY ID is INVALID")
 	ErrNilCategoryId     = errors.New("the given CATEGORY ID is NIL")
 	ErrNilCombinations   = errors.New("the given ATTR COMBINATIONS are NIL")
+	ErrCategoryNotFound  = errors.New("the given CATEGORY does NOT EXISTS")
+	ErrNilCategoryPath   = errors.New("the given CATEGORY PATH is NIL")
+	ErrNilSiteId         = errors.New("the given SITE ID is NIL")
+
+	ErrMissingRequiredAttr        = errors.New("the REQUIRED ATTRIBUTE is MISSING")
+	ErrMissingCatalogRequiredAttr = errors.New("the CATALOG REQUIRED ATTRIBUTE is MISSING")
+	ErrAttrValueTooLong           = errors.New("the ATTRIBUTE VALUE exceeds its MAX LENGTH")
+	ErrInvalidAttrValueType       = errors.New("the ATTRIBUTE VALUE does NOT MATCH its VALUE TYPE")
+	ErrInvalidAttrValueId         = errors.New("the ATTRIBUTE VALUE ID is NOT ALLOWED")
+	ErrInvalidAttrValue           = errors.New("the ATTRIBUTE VALUE is NOT ALLOWED")
+	ErrInvalidAttrUnit            = errors.New("the ATTRIBUTE UNIT is NOT ALLOWED")
+	ErrNilAttrValue               = errors.New("the given ATTRIBUTE VALUE is NIL")
+	ErrUnknownUnit                = errors.New("the given UNIT is UNKNOWN")
+	ErrIncompatibleUnits          = errors.New("the given UNITS are INCOMPATIBLE")
 
 	ErrInvalidBuyingMode = errors.New("the BUYING MODE is invalid")
 	ErrInvalidCondition  = errors.New("the CONDITION is invalid")
@@ -41,6 +55,74 @@ var (
 	ErrRemoteInconsistency = errors.New("the SERVER had an inconsistency while performing a request (status code != real behaviour)")
 
 	ErrInvalidMultigetQuantity = errors.New("invalid quantity of elements for multiget request type")
+
+	ErrNilOrderId  = errors.New("the given ORDER ID is NIL")
+	ErrNilOrder    = errors.New("the given ORDER is NIL")
+	ErrNilSellerId = errors.New("the given SELLER ID is NIL")
+
+	ErrNilQuestionId     = errors.New("the given QUESTION ID is NIL")
+	ErrNilQuestionSearch = errors.New("the QUESTION SEARCH needs an ITEM or a SELLER")
+	ErrNilAnswer         = errors.New("the given ANSWER is NIL")
+	ErrNilUserId         = errors.New("the given USER ID is NIL")
+
+	ErrNilShipmentId      = errors.New("the given SHIPMENT ID is NIL")
+	ErrInvalidDimensions  = errors.New("the given DIMENSIONS are INVALID (expected HxWxL,WEIGHT)")
+	ErrInvalidLabelFormat = errors.New("the given LABEL FORMAT is INVALID")
+
+	ErrInvalidShippingMode  = errors.New("the SHIPPING MODE is NOT ALLOWED")
+	ErrNilShippingCosts     = errors.New("the CUSTOM SHIPPING has NIL COSTS")
+	ErrTooManyShippingCosts = errors.New("the CUSTOM SHIPPING exceeds its MAX COSTS")
+	ErrInvalidFreeShipping  = errors.New("the SHIPPING MODE does NOT ALLOW FREE SHIPPING")
+	ErrNilDimensions        = errors.New("the given DIMENSIONS are NIL")
+
+	ErrNilSearchQuery       = errors.New("the SEARCH needs a QUERY, a CATEGORY or a SELLER")
+	ErrSearchOffsetExceeded = errors.New("the SEARCH OFFSET exceeds the MAX the remote allows")
+	ErrReservedSearchFilter = errors.New("the SEARCH FILTER overrides a PARAM of the QUERY")
+
+	ErrInactiveUser                = errors.New("the USER is NOT ACTIVE")
+	ErrLowReputation               = errors.New("the USER REPUTATION LEVEL is BELOW the MIN")
+	ErrClaimsRateExceeded          = errors.New("the USER CLAIMS RATE exceeds the MAX")
+	ErrCancellationsRateExceeded   = errors.New("the USER CANCELLATIONS RATE exceeds the MAX")
+	ErrDelayedHandlingRateExceeded = errors.New("the USER DELAYED HANDLING RATE exceeds the MAX")
+
+	ErrNilCurrencyId     = errors.New("the given CURRENCY ID is NIL")
+	ErrInvalidCurrencyId = errors.New("the CURRENCY is NOT ALLOWED on the SITE")
+
+	ErrInvalidVisitsWindow = errors.New("the VISITS WINDOW needs a LAST quantity of DAYS or HOURS")
+
+	ErrNilPromotionId        = errors.New("the given PROMOTION ID is NIL")
+	ErrNilPromotionType      = errors.New("the given PROMOTION TYPE is NIL")
+	ErrNilDealPrice          = errors.New("the given DEAL PRICE is NIL")
+	ErrInvalidPromotionDates = errors.New("the PROMOTION needs a START DATE BEFORE its FINISH DATE")
+
+	ErrInvalidPriceRule = errors.New("the PRICE RULE is INVALID")
+	ErrNilCostSource    = errors.New("the given COST SOURCE is NIL")
+
+	ErrNilCatalogProductId = errors.New("the given CATALOG PRODUCT ID is NIL")
+	ErrNilCatalogSearch    = errors.New("the CATALOG SEARCH needs a PRODUCT IDENTIFIER or a QUERY")
+
+	ErrFewPictures          = errors.New("the PRODUCT has LESS PICTURES than RECOMMENDED")
+	ErrTooManyPictures      = errors.New("the PRODUCT exceeds the MAX PICTURES of its CATEGORY")
+	ErrProductTitleTooShort = errors.New("the PRODUCT TITLE is SHORTER than RECOMMENDED")
+	ErrProductTitleTooLong  = errors.New("the PRODUCT TITLE exceeds the MAX LENGTH of its CATEGORY")
+	ErrNilDescription       = errors.New("the PRODUCT DESCRIPTION is NIL")
+
+	ErrNilPackId      = errors.New("the given PACK ID is NIL")
+	ErrNilMessageId   = errors.New("the given MESSAGE ID is NIL")
+	ErrNilMessageText = errors.New("the MESSAGE TEXT is NIL")
+	ErrNilAttachment  = errors.New("the given ATTACHMENT is NIL")
+
+	ErrNilClaimId   = errors.New("the given CLAIM ID is NIL")
+	ErrNilClaimRole = errors.New("the given CLAIM ROLE is NIL")
+
+	ErrNilWebhook           = errors.New("the given WEBHOOK is NIL")
+	ErrInvalidWebhook       = errors.New("the given WEBHOOK is INVALID")
+	ErrInvalidApplicationId = errors.New("the WEBHOOK APPLICATION ID does NOT MATCH the credentials")
+	ErrUnhandledTopic       = errors.New("the WEBHOOK TOPIC has NO HANDLER")
+	ErrInvalidResource      = errors.New("the WEBHOOK RESOURCE is INVALID")
+	ErrClosedWebhookHandler = errors.New("the WEBHOOK HANDLER is CLOSED")
+	ErrQueueFull            = errors.New("the WEBHOOK QUEUE is FULL")
+	ErrEmptyQueue           = errors.New("the WEBHOOK QUEUE is EMPTY")
 )
 
 type Error struct {
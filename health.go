@@ -0,0 +1,356 @@
+package meli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// SubStatus details the status of a listing (e.g. why is it paused)
+type SubStatus string
+
+const (
+	SubStatusOutOfStock           SubStatus = "out_of_stock"
+	SubStatusWarning              SubStatus = "warning"
+	SubStatusWaitingForPatch      SubStatus = "waiting_for_patch"
+	SubStatusHeld                 SubStatus = "held"
+	SubStatusPendingDocumentation SubStatus = "pending_documentation"
+	SubStatusForbidden            SubStatus = "forbidden"
+	SubStatusFreezed              SubStatus = "freezed"
+	SubStatusDeleted              SubStatus = "deleted"
+)
+
+// Warning is a problem the remote found on the listing, which can lead to its pausing
+type Warning struct {
+	Department string   `json:"department,omitempty"`
+	CauseId    int      `json:"cause_id,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	Code       string   `json:"code,omitempty"`
+	References []string `json:"references,omitempty"`
+	Message    string   `json:"message,omitempty"`
+}
+
+func (prod *Product) HasSubStatus(subStatus SubStatus) bool {
+	for _, s := range prod.SubStatus {
+		if s == subStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemHealth is the quality of a listing as measured by the remote, from 0 to 1
+type ItemHealth struct {
+	ItemId ProductId     `json:"item_id"`
+	Health float64       `json:"health"`
+	Level  string        `json:"level,omitempty"` // e.g. basic, standard, professional
+	Goals  []*HealthGoal `json:"goals,omitempty"`
+}
+
+const (
+	HealthGoalPictures    = "pictures"
+	HealthGoalAttributes  = "technical_specification"
+	HealthGoalDescription = "description"
+)
+
+// HealthGoal is an aspect of the listing which improves its health once completed
+type HealthGoal struct {
+	Id          string     `json:"id"`
+	Progress    float64    `json:"progress"`
+	ProgressMax float64    `json:"progress_max"`
+	Apply       bool       `json:"apply"`
+	Completed   *time.Time `json:"completed,omitempty"`
+}
+
+func (goal *HealthGoal) Pending() bool {
+	return goal.Apply && goal.Progress < goal.ProgressMax
+}
+
+// PendingGoals retrieves the goals which apply to the listing but aren't completed yet
+func (h *ItemHealth) PendingGoals() []*HealthGoal {
+	var pending []*HealthGoal
+	for _, goal := range h.Goals {
+		if goal.Pending() {
+			pending = append(pending, goal)
+		}
+	}
+	return pending
+}
+
+// HealthAction is an action the seller can perform to improve the health of the listing
+type HealthAction struct {
+	Id   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func (ml *MeLi) GetItemHealth(itemId ProductId) (*ItemHealth, error) {
+	if itemId == "" {
+		return nil, ErrNilProductId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/items/%v/health", params, itemId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	health := &ItemHealth{}
+	err = json.NewDecoder(resp.Body).Decode(health)
+	if err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+func (ml *MeLi) ItemHealthActions(itemId ProductId) ([]*HealthAction, error) {
+	if itemId == "" {
+		return nil, ErrNilProductId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/items/%v/health/actions", params, itemId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	body := &struct {
+		Actions []*HealthAction `json:"actions"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Actions, nil
+}
+
+// HealthReport combines the health of many listings
+type HealthReport struct {
+	// Items is aligned with the requested listings, having nil on the failed ones
+	Items []*ItemHealth
+	// Failed are the errs produced while retrieving the health of each failed listing
+	Failed map[ProductId]error
+	// Average is the mean health of the retrieved listings
+	Average float64
+	// PendingGoals is the quantity of listings on which each goal is pending
+	PendingGoals map[string]int
+}
+
+// SellerHealthReport retrieves the health of every listing of the seller, whichever its status is (see ScanAllProducts)
+func (ml *MeLi) SellerHealthReport(sellerId int) (*HealthReport, error) {
+	itemIds, err := ml.ScanAllProducts(sellerId, "")
+	if err != nil {
+		return nil, err
+	}
+	return ml.ItemsHealthReport(itemIds...), nil
+}
+
+// ItemsHealthReport retrieves the health of the given listings, performing up to 4 requests at once
+func (ml *MeLi) ItemsHealthReport(itemIds ...ProductId) *HealthReport {
+	report := &HealthReport{
+		Items:        make([]*ItemHealth, len(itemIds)),
+		Failed:       make(map[ProductId]error),
+		PendingGoals: make(map[string]int),
+	}
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	sem := make(chan struct{}, 4)
+	for i, itemId := range itemIds {
+		i, itemId := i, itemId
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			health, err := ml.GetItemHealth(itemId)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				report.Failed[itemId] = err
+				return
+			}
+			report.Items[i] = health
+		}()
+	}
+	wg.Wait()
+
+	var retrieved int
+	for _, health := range report.Items {
+		if health == nil {
+			continue
+		}
+		retrieved++
+		report.Average += health.Health
+		for _, goal := range health.PendingGoals() {
+			report.PendingGoals[goal.Id]++
+		}
+	}
+	if retrieved > 0 {
+		report.Average /= float64(retrieved)
+	}
+	return report
+}
+
+const (
+	// qualityPictures is the recommended quantity of pictures of a listing
+	qualityPictures = 6
+	// qualityMinTitleLength is the recommended min length of the title of a listing
+	qualityMinTitleLength = 20
+)
+
+type QualityCheck string
+
+const (
+	QualityPictures    QualityCheck = "pictures"
+	QualityTitle       QualityCheck = "title"
+	QualityAttributes  QualityCheck = "attributes"
+	QualityDescription QualityCheck = "description"
+)
+
+type QualityIssue struct {
+	Check QualityCheck
+	Err   error
+}
+
+func (issue *QualityIssue) Error() string {
+	return fmt.Sprintf("%s: %v", issue.Check, issue.Err)
+}
+
+// QualityReport is the local estimation of the quality of a product, from 0 to 1, with the issues which lower it
+type QualityReport struct {
+	Score  float64
+	Scores map[QualityCheck]float64
+	Issues []*QualityIssue
+}
+
+func (r *QualityReport) add(check QualityCheck, score float64, errs ...error) {
+	r.Scores[check] = score
+	for _, err := range errs {
+		r.Issues = append(r.Issues, &QualityIssue{Check: check, Err: err})
+	}
+}
+
+// ProductQuality scores the product against its category before publishing it
+func (ml *MeLi) ProductQuality(prod *Product) (*QualityReport, error) {
+	if prod == nil {
+		return nil, ErrNilProduct
+	}
+	cat, err := ml.GetCategory(prod.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+	catAttrs, err := ml.CategoryAttributes(prod.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+	return prod.Quality(cat, catAttrs), nil
+}
+
+// Quality scores the pictures, title, attributes and description of the product, equally weighted.
+// The category and its attributes definitions are optional, but the checks are looser without them
+func (prod *Product) Quality(cat *Category, catAttrs []*Attribute) *QualityReport {
+	settings := &CategorySettings{}
+	if cat != nil && cat.Settings != nil {
+		settings = cat.Settings
+	}
+	report := &QualityReport{Scores: make(map[QualityCheck]float64)}
+	prod.scorePictures(settings, report)
+	prod.scoreTitle(settings, report)
+	prod.scoreAttributes(catAttrs, report)
+	if !prod.hasDescription() {
+		report.add(QualityDescription, 0, ErrNilDescription)
+	} else {
+		report.add(QualityDescription, 1)
+	}
+	for _, score := range report.Scores {
+		report.Score += score
+	}
+	report.Score /= float64(len(report.Scores))
+	return report
+}
+
+// hasDescription reports if the product has a description to be published or, once published, the id of one
+func (prod *Product) hasDescription() bool {
+	if prod.Description.PlainText != "" {
+		return true
+	}
+	for _, desc := range prod.Descriptions {
+		if desc.Id != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (prod *Product) scorePictures(settings *CategorySettings, report *QualityReport) {
+	pics := len(prod.Pictures)
+	if settings.MaxPicturesPerItem > 0 && pics > settings.MaxPicturesPerItem {
+		report.add(QualityPictures, 0, ErrTooManyPictures)
+		return
+	}
+	want := qualityPictures
+	if settings.MaxPicturesPerItem > 0 && settings.MaxPicturesPerItem < want {
+		want = settings.MaxPicturesPerItem
+	}
+	if pics < want {
+		report.add(QualityPictures, float64(pics)/float64(want), ErrFewPictures)
+		return
+	}
+	report.add(QualityPictures, 1)
+}
+
+func (prod *Product) scoreTitle(settings *CategorySettings, report *QualityReport) {
+	length := utf8.RuneCountInString(prod.Title)
+	switch {
+	case settings.MaxTitleLength > 0 && length > settings.MaxTitleLength:
+		report.add(QualityTitle, 0, ErrProductTitleTooLong)
+	case length == 0:
+		report.add(QualityTitle, 0, ErrNilProductTitle)
+	case length < qualityMinTitleLength:
+		report.add(QualityTitle, 0.5, ErrProductTitleTooShort)
+	default:
+		report.add(QualityTitle, 1)
+	}
+}
+
+// scoreAttributes scores the ratio of the attributes of the category filled by the product.
+// The hidden and read only ones aren't taken into account, since the seller can't fill them
+func (prod *Product) scoreAttributes(catAttrs []*Attribute, report *QualityReport) {
+	var errs []error
+	for _, v := range prod.ValidateAttributes(catAttrs).Violations {
+		errs = append(errs, v)
+	}
+	var fillable, filled int
+	for _, def := range catAttrs {
+		if def.tagValue("hidden") || def.tagValue("read_only") {
+			continue
+		}
+		fillable++
+		if len(prod.attributes(def.Id)) > 0 {
+			filled++
+		}
+	}
+	if fillable == 0 {
+		report.add(QualityAttributes, 1, errs...)
+		return
+	}
+	report.add(QualityAttributes, float64(filled)/float64(fillable), errs...)
+}
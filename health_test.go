@@ -0,0 +1,313 @@
+package meli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_GetItemHealth(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		itemId     ProductId
+		stub       *httpstub.Stub
+		wantHealth *ItemHealth
+		wantErr    error
+	}{
+		{
+			name:    "NIL ITEM ID",
+			wantErr: ErrNilProductId,
+		},
+		{
+			name:   "REMOTE returns an ERR",
+			itemId: "MLA1",
+			stub: &httpstub.Stub{Status: 404,
+				URL:     "/items/MLA1/health",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			itemId: "MLA1",
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/items/MLA1/health",
+				Body: &ItemHealth{ItemId: "MLA1", Health: 0.75, Level: "standard", Goals: []*HealthGoal{
+					{Id: HealthGoalPictures, Progress: 1, ProgressMax: 1, Apply: true},
+					{Id: HealthGoalAttributes, Progress: 0, ProgressMax: 1, Apply: true},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantHealth: &ItemHealth{ItemId: "MLA1", Health: 0.75, Level: "standard", Goals: []*HealthGoal{
+				{Id: HealthGoalPictures, Progress: 1, ProgressMax: 1, Apply: true},
+				{Id: HealthGoalAttributes, Progress: 0, ProgressMax: 1, Apply: true},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotHealth, err := ml.GetItemHealth(tt.itemId)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.GetItemHealth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantHealth, gotHealth); diff != "" {
+				t.Errorf("MeLi.GetItemHealth() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_ItemHealthActions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		itemId      ProductId
+		stub        *httpstub.Stub
+		wantActions []*HealthAction
+		wantErr     error
+	}{
+		{
+			name:    "NIL ITEM ID",
+			wantErr: ErrNilProductId,
+		},
+		{
+			name:   "REMOTE returns an ERR",
+			itemId: "MLA1",
+			stub: &httpstub.Stub{Status: 404,
+				URL:     "/items/MLA1/health/actions",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			itemId: "MLA1",
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/items/MLA1/health/actions",
+				Body: map[string][]*HealthAction{"actions": {
+					{Id: "add_pictures", Name: "Add pictures"},
+					{Id: "add_technical_specification", Name: "Add technical specification"},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantActions: []*HealthAction{
+				{Id: "add_pictures", Name: "Add pictures"},
+				{Id: "add_technical_specification", Name: "Add technical specification"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotActions, err := ml.ItemHealthActions(tt.itemId)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ItemHealthActions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantActions, gotActions); diff != "" {
+				t.Errorf("MeLi.ItemHealthActions() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_ItemsHealthReport(t *testing.T) {
+	t.Parallel()
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	params := httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}}
+	stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{
+		{Status: 200, URL: "/items/MLA1/health", Receive: params,
+			Body: &ItemHealth{ItemId: "MLA1", Health: 1, Goals: []*HealthGoal{
+				{Id: HealthGoalPictures, Progress: 1, ProgressMax: 1, Apply: true},
+			}},
+		},
+		{Status: 200, URL: "/items/MLA2/health", Receive: params,
+			Body: &ItemHealth{ItemId: "MLA2", Health: 0.5, Goals: []*HealthGoal{
+				{Id: HealthGoalPictures, Progress: 0, ProgressMax: 1, Apply: true},
+				{Id: HealthGoalDescription, Progress: 0, ProgressMax: 1, Apply: false},
+			}},
+		},
+		{Status: 404, URL: "/items/MLA3/health", Receive: params, Body: svErrFooBar},
+	}, Client: ml}
+	cleanup := stubber.Serve(t)
+	defer cleanup()
+
+	report := ml.ItemsHealthReport("MLA1", "MLA2", "MLA3")
+	if len(report.Items) != 3 || report.Items[0].ItemId != "MLA1" || report.Items[1].ItemId != "MLA2" || report.Items[2] != nil {
+		t.Errorf("MeLi.ItemsHealthReport() items aren't aligned with the requested ones: %v", report.Items)
+	}
+	if fmt.Sprintf("%v", report.Failed["MLA3"]) != fmt.Sprintf("%v", svErrFooBar) {
+		t.Errorf("MeLi.ItemsHealthReport() failed = %v, want %v", report.Failed, svErrFooBar)
+	}
+	if report.Average != 0.75 {
+		t.Errorf("MeLi.ItemsHealthReport() average = %v, want %v", report.Average, 0.75)
+	}
+	if diff := cmp.Diff(map[string]int{HealthGoalPictures: 1}, report.PendingGoals); diff != "" {
+		t.Errorf("MeLi.ItemsHealthReport() pending goals mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestMeLi_SellerHealthReport(t *testing.T) {
+	t.Parallel()
+	if _, err := (&MeLi{creds: &creds{Access: "foo"}}).SellerHealthReport(0); err != ErrNilSellerId {
+		t.Errorf("MeLi.SellerHealthReport() error = %v, wantErr %v", err, ErrNilSellerId)
+	}
+	// The listings of the seller are scanned across pages
+	pages := map[string]string{
+		"":    `{"results":["MLA1","MLA2"],"scroll_id":"bar"}`,
+		"bar": `{"results":["MLA3"],"scroll_id":"baz"}`,
+		"baz": `{"results":[],"scroll_id":"baz"}`,
+	}
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		query := req.URL.Query()
+		switch {
+		case req.URL.Path == "/users/1/items/search" && query.Get("search_type") == "scan":
+			resp.Body = ioutil.NopCloser(strings.NewReader(pages[query.Get("scroll_id")]))
+		case strings.HasSuffix(req.URL.Path, "/health"):
+			itemId := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/items/"), "/health")
+			resp.Body = ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"item_id":%q,"health":0.5}`, itemId)))
+		default:
+			return nil, fmt.Errorf("unexpected request to %v", req.URL)
+		}
+		return resp, nil
+	})})
+
+	report, err := ml.SellerHealthReport(1)
+	if err != nil {
+		t.Fatalf("MeLi.SellerHealthReport() error = %v", err)
+	}
+	want := []*ItemHealth{{ItemId: "MLA1", Health: 0.5}, {ItemId: "MLA2", Health: 0.5}, {ItemId: "MLA3", Health: 0.5}}
+	if diff := cmp.Diff(want, report.Items); diff != "" {
+		t.Errorf("MeLi.SellerHealthReport() items mismatch (-want +got): %s", diff)
+	}
+	if report.Average != 0.5 {
+		t.Errorf("MeLi.SellerHealthReport() average = %v, want %v", report.Average, 0.5)
+	}
+}
+
+func TestProduct_Quality(t *testing.T) {
+	t.Parallel()
+	cat := &Category{Settings: &CategorySettings{MaxPicturesPerItem: 10, MaxTitleLength: 60}}
+	catAttrs := []*Attribute{
+		{Id: "BRAND", Tags: []Tag{{"required": true}}},
+		{Id: "MODEL"},
+		{Id: "ITEM_CONDITION", Tags: []Tag{{"read_only": true}}},
+	}
+	pics := func(n int) []*Picture {
+		pics := make([]*Picture, n)
+		for i := range pics {
+			pics[i] = &Picture{}
+		}
+		return pics
+	}
+	tests := []struct {
+		name       string
+		prod       func() *Product
+		cat        *Category
+		catAttrs   []*Attribute
+		wantScore  float64
+		wantIssues []string
+	}{
+		{
+			name: "COMPLETE",
+			prod: func() *Product {
+				prod := &Product{Title: "Celular Foo Bar 64gb Negro", Pictures: pics(6)}
+				prod.Attributes = []*Attribute{{Id: "BRAND", ValueName: "Foo"}, {Id: "MODEL", ValueName: "Bar"}}
+				prod.Description.PlainText = "baz"
+				return prod
+			},
+			cat:       cat,
+			catAttrs:  catAttrs,
+			wantScore: 1,
+		},
+		{
+			name: "FEW PICTURES, SHORT TITLE, MISSING ATTRS and NIL DESCRIPTION",
+			prod: func() *Product {
+				return &Product{Title: "Celular", Pictures: pics(3), Attributes: []*Attribute{{Id: "MODEL", ValueName: "Bar"}}}
+			},
+			cat:       cat,
+			catAttrs:  catAttrs,
+			wantScore: (0.5 + 0.5 + 0.5 + 0) / 4,
+			wantIssues: []string{
+				fmt.Sprintf("pictures: %v", ErrFewPictures),
+				fmt.Sprintf("title: %v", ErrProductTitleTooShort),
+				fmt.Sprintf("attributes: %v", (&AttributeViolation{AttributeId: "BRAND", Err: ErrMissingRequiredAttr}).Error()),
+				fmt.Sprintf("description: %v", ErrNilDescription),
+			},
+		},
+		{
+			name: "TOO MANY PICTURES and TOO LONG TITLE",
+			prod: func() *Product {
+				prod := &Product{Title: strings.Repeat("a", 61), Pictures: pics(11)}
+				prod.Description.PlainText = "baz"
+				return prod
+			},
+			cat:       cat,
+			wantScore: (0 + 0 + 1 + 1) / 4.0,
+			wantIssues: []string{
+				fmt.Sprintf("pictures: %v", ErrTooManyPictures),
+				fmt.Sprintf("title: %v", ErrProductTitleTooLong),
+			},
+		},
+		{
+			name: "DESCRIPTION is already PUBLISHED",
+			prod: func() *Product {
+				prod := &Product{Title: strings.Repeat("a", 100), Pictures: pics(6)}
+				prod.Descriptions = append(prod.Descriptions, struct {
+					Id string `json:"id,omitempty"`
+				}{Id: "MLA1-1"})
+				return prod
+			},
+			wantScore: 1,
+		},
+		{
+			name: "NIL CATEGORY",
+			prod: func() *Product {
+				prod := &Product{Title: strings.Repeat("a", 100), Pictures: pics(20)}
+				prod.Description.PlainText = "baz"
+				return prod
+			},
+			wantScore: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			report := tt.prod().Quality(tt.cat, tt.catAttrs)
+			if report.Score != tt.wantScore {
+				t.Errorf("Product.Quality() score = %v, want %v", report.Score, tt.wantScore)
+			}
+			var gotIssues []string
+			for _, issue := range report.Issues {
+				gotIssues = append(gotIssues, issue.Error())
+			}
+			if diff := cmp.Diff(tt.wantIssues, gotIssues); diff != "" {
+				t.Errorf("Product.Quality() issues mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
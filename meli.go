@@ -1,19 +1,26 @@
 package meli
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 )
 
 type MeLi struct {
 	http.Client
 
-	creds *creds
+	creds  *creds
+	meLock sync.Mutex
+
+	cache      Cache
+	cacheStats CacheStats
 }
 
 func (ml *MeLi) SetClient(c http.Client) {
@@ -133,3 +140,35 @@ func (ml *MeLi) Put(url string, body io.Reader) (resp *http.Response, err error)
 	req.Header.Set("Content-Type", "application/json")
 	return ml.Do(req)
 }
+
+func (ml *MeLi) Delete(url string) (resp *http.Response, err error) {
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ml.Do(req)
+}
+
+// postFile posts the content read from r as the file of the given field of a multipart form
+func (ml *MeLi) postFile(url, field, filename string, r io.Reader) (resp *http.Response, err error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile(field, filename)
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.Copy(part, r)
+	if err != nil {
+		return nil, err
+	}
+	err = form.Close()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return ml.Do(req)
+}
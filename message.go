@@ -0,0 +1,317 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// messagesTag is the tag of the post-sale conversations, which every messaging call needs
+const messagesTag = "post_sale"
+
+type MessageId string
+
+// AttachmentId is the id of an uploaded file, which can be attached to a message afterwards
+type AttachmentId string
+
+// Message is a message of the post-sale conversation of a pack (or of an order, if it doesn't belong to any)
+type Message struct {
+	Id          MessageId            `json:"id,omitempty"`
+	SiteId      SiteId               `json:"site_id,omitempty"`
+	From        *MessageParty        `json:"from,omitempty"`
+	To          *MessageParty        `json:"to,omitempty"`
+	Status      MessageStatus        `json:"status,omitempty"`
+	Text        string               `json:"text,omitempty"`
+	Dates       *MessageDates        `json:"message_date,omitempty"`
+	Moderation  *MessageModeration   `json:"message_moderation,omitempty"`
+	Attachments []*MessageAttachment `json:"message_attachments,omitempty"`
+	Resources   []*MessageResource   `json:"message_resources,omitempty"`
+}
+
+type MessageStatus string
+
+const (
+	MessageAvailable MessageStatus = "available"
+	MessageModerated MessageStatus = "moderated"
+	MessageRejected  MessageStatus = "rejected"
+	MessagePending   MessageStatus = "pending_translation"
+)
+
+type MessageParty struct {
+	UserId int `json:"user_id"`
+}
+
+type MessageDates struct {
+	Received  *time.Time `json:"received,omitempty"`
+	Available *time.Time `json:"available,omitempty"`
+	Notified  *time.Time `json:"notified,omitempty"`
+	Created   *time.Time `json:"created,omitempty"`
+	Read      *time.Time `json:"read,omitempty"`
+}
+
+type MessageModeration struct {
+	Status      string     `json:"status,omitempty"` // e.g. clean, rejected
+	Reason      string     `json:"reason,omitempty"`
+	Source      string     `json:"source,omitempty"`
+	ModeratedAt *time.Time `json:"moderation_date,omitempty"`
+}
+
+type MessageAttachment struct {
+	Filename         AttachmentId `json:"filename"`
+	OriginalFilename string       `json:"original_filename,omitempty"`
+	Type             string       `json:"type,omitempty"`
+	Size             int          `json:"size,omitempty"`
+}
+
+// MessageResource is an entity the message is about (e.g. the pack or order)
+type MessageResource struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Read reports if the recipient already read the message
+func (msg *Message) Read() bool {
+	return msg.Dates != nil && msg.Dates.Read != nil
+}
+
+// MessageSearch filters the messages of the conversation of a pack with its seller
+type MessageSearch struct {
+	PackId   int64
+	SellerId int
+	// MarkAsRead marks the retrieved messages as read by the seller. Otherwise, they're kept unread
+	MarkAsRead bool
+	Offset     int
+	Limit      int
+}
+
+type MessageEdge struct {
+	Paging             Paging `json:"paging"`
+	ConversationStatus struct {
+		Status    string `json:"status,omitempty"` // e.g. active, blocked
+		Substatus string `json:"substatus,omitempty"`
+	} `json:"conversation_status"`
+	Messages []*Message `json:"messages"`
+}
+
+// PackMessages retrieves a page of the conversation of the given pack
+func (ml *MeLi) PackMessages(search *MessageSearch) (*MessageEdge, error) {
+	if search == nil || search.PackId == 0 {
+		return nil, ErrNilPackId
+	}
+	if search.SellerId == 0 {
+		return nil, ErrNilSellerId
+	}
+	params, err := ml.messagesParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("mark_as_read", strconv.FormatBool(search.MarkAsRead))
+	if search.Offset > 0 {
+		params.Set("offset", strconv.Itoa(search.Offset))
+	}
+	if search.Limit > 0 {
+		params.Set("limit", strconv.Itoa(search.Limit))
+	}
+	URL, err := ml.RouteTo("/messages/packs/%v/sellers/%v", params, search.PackId, search.SellerId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &MessageEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+func (ml *MeLi) GetMessage(id MessageId) (*Message, error) {
+	if id == "" {
+		return nil, ErrNilMessageId
+	}
+	params, err := ml.messagesParams()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/messages/%v", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	msg := &Message{}
+	err = json.NewDecoder(resp.Body).Decode(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// OutgoingMessage is a message of the seller to the buyer of a pack
+type OutgoingMessage struct {
+	BuyerId     int
+	Text        string
+	Attachments []AttachmentId
+}
+
+type sendMessageRequest struct {
+	From        *MessageParty  `json:"from"`
+	To          *MessageParty  `json:"to"`
+	Text        string         `json:"text"`
+	Attachments []AttachmentId `json:"attachments,omitempty"`
+}
+
+// SendMessage sends the message onto the conversation of the given pack, retrieving it as created
+func (ml *MeLi) SendMessage(packId int64, sellerId int, msg *OutgoingMessage) (*Message, error) {
+	if packId == 0 {
+		return nil, ErrNilPackId
+	}
+	if sellerId == 0 {
+		return nil, ErrNilSellerId
+	}
+	if msg == nil || msg.BuyerId == 0 {
+		return nil, ErrNilUserId
+	}
+	if msg.Text == "" {
+		return nil, ErrNilMessageText
+	}
+	params, err := ml.messagesParams()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/messages/packs/%v/sellers/%v", params, packId, sellerId)
+	if err != nil {
+		return nil, err
+	}
+	reqBody, err := json.Marshal(&sendMessageRequest{
+		From:        &MessageParty{UserId: sellerId},
+		To:          &MessageParty{UserId: msg.BuyerId},
+		Text:        msg.Text,
+		Attachments: msg.Attachments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Post(URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	created := &Message{}
+	err = json.NewDecoder(resp.Body).Decode(created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UploadAttachment uploads the file read from r, retrieving the id to attach it to a message
+func (ml *MeLi) UploadAttachment(filename string, r io.Reader) (AttachmentId, error) {
+	if filename == "" || r == nil {
+		return "", ErrNilAttachment
+	}
+	params, err := ml.messagesParams()
+	if err != nil {
+		return "", err
+	}
+	URL, err := ml.RouteTo("/messages/attachments", params)
+	if err != nil {
+		return "", err
+	}
+	resp, err := ml.postFile(URL, "file", filename, r)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", errFromReader(resp.Body)
+	}
+	uploaded := &struct {
+		Id AttachmentId `json:"id"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(uploaded)
+	if err != nil {
+		return "", err
+	}
+	return uploaded.Id, nil
+}
+
+// MarkMessagesRead marks the given messages as read by the seller
+func (ml *MeLi) MarkMessagesRead(ids ...MessageId) error {
+	if len(ids) == 0 {
+		return ErrNilMessageId
+	}
+	strIds := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return ErrNilMessageId
+		}
+		strIds = append(strIds, string(id))
+	}
+	params, err := ml.messagesParams()
+	if err != nil {
+		return err
+	}
+	URL, err := ml.RouteTo("/messages/mark_as_read/%v", params, strings.Join(strIds, ","))
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Put(URL, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
+func (ml *MeLi) messagesParams() (url.Values, error) {
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("tag", messagesTag)
+	return params, nil
+}
+
+// ProcessMessageWebhook retrieves the notified message. The messages topic notifies its bare id as resource
+func (ml *MeLi) ProcessMessageWebhook(wh *Webhook) (*Message, error) {
+	id, err := wh.resourceIdOf(ResourceMessages)
+	if err != nil {
+		return nil, err
+	}
+	return ml.GetMessage(MessageId(id))
+}
+
+// MessageWebhookFunc adapts fn to be registered for the messages topic, retrieving the notified message for it
+func (ml *MeLi) MessageWebhookFunc(fn func(msg *Message) error) WebhookFunc {
+	return func(wh *Webhook) error {
+		msg, err := ml.ProcessMessageWebhook(wh)
+		if err != nil {
+			return err
+		}
+		return fn(msg)
+	}
+}
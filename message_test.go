@@ -0,0 +1,300 @@
+package meli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_PackMessages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		search   *MessageSearch
+		stub     *httpstub.Stub
+		wantEdge *MessageEdge
+		wantErr  error
+	}{
+		{
+			name:    "NIL PACK ID",
+			search:  &MessageSearch{SellerId: 1},
+			wantErr: ErrNilPackId,
+		},
+		{
+			name:    "NIL SELLER ID",
+			search:  &MessageSearch{PackId: 2},
+			wantErr: ErrNilSellerId,
+		},
+		{
+			name:   "REMOTE returns an ERR",
+			search: &MessageSearch{PackId: 2, SellerId: 1},
+			stub: &httpstub.Stub{Status: 403,
+				URL:  "/messages/packs/2/sellers/1",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"}, "tag": []string{"post_sale"}, "mark_as_read": []string{"false"},
+				}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			search: &MessageSearch{PackId: 2, SellerId: 1, MarkAsRead: true, Limit: 10},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/messages/packs/2/sellers/1",
+				Body: &MessageEdge{Paging: Paging{Total: 1}, Messages: []*Message{
+					{Id: "abc", From: &MessageParty{UserId: 3}, To: &MessageParty{UserId: 1}, Status: MessageAvailable, Text: "bar"},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"},
+					"tag":          []string{"post_sale"},
+					"mark_as_read": []string{"true"},
+					"limit":        []string{"10"},
+				}},
+			},
+			wantEdge: &MessageEdge{Paging: Paging{Total: 1}, Messages: []*Message{
+				{Id: "abc", From: &MessageParty{UserId: 3}, To: &MessageParty{UserId: 1}, Status: MessageAvailable, Text: "bar"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotEdge, err := ml.PackMessages(tt.search)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.PackMessages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantEdge, gotEdge); diff != "" {
+				t.Errorf("MeLi.PackMessages() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_SendMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		packId  int64
+		msg     *OutgoingMessage
+		stub    *httpstub.Stub
+		wantMsg *Message
+		wantErr error
+	}{
+		{
+			name:    "NIL PACK ID",
+			msg:     &OutgoingMessage{BuyerId: 3, Text: "bar"},
+			wantErr: ErrNilPackId,
+		},
+		{
+			name:    "NIL BUYER",
+			packId:  2,
+			msg:     &OutgoingMessage{Text: "bar"},
+			wantErr: ErrNilUserId,
+		},
+		{
+			name:    "NIL TEXT",
+			packId:  2,
+			msg:     &OutgoingMessage{BuyerId: 3, Attachments: []AttachmentId{"baz.pdf"}},
+			wantErr: ErrNilMessageText,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			packId: 2,
+			msg:    &OutgoingMessage{BuyerId: 3, Text: "bar", Attachments: []AttachmentId{"baz.pdf"}},
+			stub: &httpstub.Stub{Status: 201,
+				URL:  "/messages/packs/2/sellers/1",
+				Body: &Message{Id: "abc", Status: MessageAvailable, Text: "bar"},
+				Receive: httpstub.Receive{
+					Params: url.Values{"access_token": []string{"foo"}, "tag": []string{"post_sale"}},
+					Body:   []byte(`{"from":{"user_id":1},"to":{"user_id":3},"text":"bar","attachments":["baz.pdf"]}`),
+				},
+			},
+			wantMsg: &Message{Id: "abc", Status: MessageAvailable, Text: "bar"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotMsg, err := ml.SendMessage(tt.packId, 1, tt.msg)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.SendMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantMsg, gotMsg); diff != "" {
+				t.Errorf("MeLi.SendMessage() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_UploadAttachment(t *testing.T) {
+	t.Parallel()
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	var reqs int
+	ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		reqs++
+		if req.URL.Path != "/messages/attachments" || req.URL.Query().Get("access_token") != "foo" {
+			t.Errorf("MeLi.UploadAttachment() requested %v", req.URL)
+		}
+		assertMultipartFile(t, req, "file", "baz.pdf", "qux")
+		return &http.Response{StatusCode: http.StatusCreated, Header: http.Header{},
+			Body: ioutil.NopCloser(strings.NewReader(`{"id":"123_baz.pdf"}`)),
+		}, nil
+	})})
+
+	if _, err := ml.UploadAttachment("", strings.NewReader("qux")); err != ErrNilAttachment {
+		t.Errorf("MeLi.UploadAttachment() error = %v, wantErr %v", err, ErrNilAttachment)
+	}
+	gotId, err := ml.UploadAttachment("baz.pdf", strings.NewReader("qux"))
+	if err != nil {
+		t.Errorf("MeLi.UploadAttachment() error = %v, wantErr %v", err, nil)
+	}
+	if gotId != "123_baz.pdf" {
+		t.Errorf("MeLi.UploadAttachment() = %v, want %v", gotId, "123_baz.pdf")
+	}
+	if reqs != 1 {
+		t.Errorf("MeLi.UploadAttachment() performed %v requests, want %v", reqs, 1)
+	}
+}
+
+// assertMultipartFile asserts the request uploads a single file on the given field
+func assertMultipartFile(t *testing.T, req *http.Request, field, filename, content string) {
+	t.Helper()
+	err := req.ParseMultipartForm(1 << 20)
+	if err != nil {
+		t.Errorf("couldn't parse the multipart body: %v", err)
+		return
+	}
+	files := req.MultipartForm.File[field]
+	if len(files) != 1 {
+		t.Errorf("multipart body has %v files on the %v field, want %v", len(files), field, 1)
+		return
+	}
+	if files[0].Filename != filename {
+		t.Errorf("multipart filename = %v, want %v", files[0].Filename, filename)
+	}
+	f, err := files[0].Open()
+	if err != nil {
+		t.Errorf("couldn't open the multipart file: %v", err)
+		return
+	}
+	defer f.Close()
+	got, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Errorf("couldn't read the multipart file: %v", err)
+		return
+	}
+	if string(got) != content {
+		t.Errorf("multipart file content = %s, want %v", got, content)
+	}
+}
+
+func TestMeLi_MarkMessagesRead(t *testing.T) {
+	t.Parallel()
+	params := url.Values{"access_token": []string{"foo"}, "tag": []string{"post_sale"}}
+	tests := []struct {
+		name    string
+		ids     []MessageId
+		stub    *httpstub.Stub
+		wantErr error
+	}{
+		{
+			name:    "NIL IDS",
+			wantErr: ErrNilMessageId,
+		},
+		{
+			name:    "a NIL ID",
+			ids:     []MessageId{"abc", ""},
+			wantErr: ErrNilMessageId,
+		},
+		{
+			name: "REMOTE returns an ERR",
+			ids:  []MessageId{"abc"},
+			stub: &httpstub.Stub{Status: 400,
+				URL:     "/messages/mark_as_read/abc",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: params},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name: "REMOTE returns CORRECTly",
+			ids:  []MessageId{"abc", "def"},
+			stub: &httpstub.Stub{Status: 200,
+				URL:     "/messages/mark_as_read/abc,def",
+				Receive: httpstub.Receive{Params: params},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			err := ml.MarkMessagesRead(tt.ids...)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.MarkMessagesRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMeLi_ProcessMessageWebhook(t *testing.T) {
+	t.Parallel()
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{
+		{Status: 200, URL: "/messages/abc",
+			Body:    &Message{Id: "abc", Text: "bar"},
+			Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}, "tag": []string{"post_sale"}}},
+		},
+	}, Client: ml}
+	cleanup := stubber.Serve(t)
+	defer cleanup()
+
+	_, err := ml.ProcessMessageWebhook(&Webhook{Topic: TopicItems, Resource: "/items/MLA1"})
+	if err != ErrInvalidResource {
+		t.Errorf("MeLi.ProcessMessageWebhook() error = %v, wantErr %v", err, ErrInvalidResource)
+	}
+	gotMsg, err := ml.ProcessMessageWebhook(&Webhook{Topic: TopicMessages, Resource: "abc"})
+	if err != nil {
+		t.Errorf("MeLi.ProcessMessageWebhook() error = %v, wantErr %v", err, nil)
+	}
+	if diff := cmp.Diff(&Message{Id: "abc", Text: "bar"}, gotMsg); diff != "" {
+		t.Errorf("MeLi.ProcessMessageWebhook() mismatch (-want +got): %s", diff)
+	}
+
+	var routed *Message
+	fn := ml.MessageWebhookFunc(func(msg *Message) error {
+		routed = msg
+		return nil
+	})
+	err = fn(&Webhook{Topic: TopicMessages, Resource: "abc"})
+	if err != nil {
+		t.Errorf("MeLi.MessageWebhookFunc() error = %v, wantErr %v", err, nil)
+	}
+	if diff := cmp.Diff(&Message{Id: "abc", Text: "bar"}, routed); diff != "" {
+		t.Errorf("MeLi.MessageWebhookFunc() mismatch (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+package meli
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// missedFeedsLimit is the quantity of notifications retrieved on each page of the missed feeds
+const missedFeedsLimit = 50
+
+type MissedFeedsEdge struct {
+	Messages []*Webhook `json:"messages"`
+	Total    int        `json:"total"`
+	Offset   int        `json:"offset"`
+	Limit    int        `json:"limit"`
+}
+
+// GetMissedFeeds retrieves a page of the notifications which couldn't be delivered to the application.
+// An empty topic retrieves the notifications of every topic
+func (ml *MeLi) GetMissedFeeds(topic Topic, offset, limit int) (*MissedFeedsEdge, error) {
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	if ml.creds.ApplicationId == "" {
+		return nil, ErrNilApplicationId
+	}
+	params.Set("app_id", string(ml.creds.ApplicationId))
+	if topic != "" {
+		params.Set("topic", string(topic))
+	}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("limit", strconv.Itoa(limit))
+	URL, err := ml.RouteTo("/missed_feeds", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &MissedFeedsEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+// MissedFeedsIterator walks every page of the missed feeds:
+//
+//	it := ml.MissedFeeds(TopicOrders)
+//	for it.Next() {
+//		wh := it.Webhook()
+//	}
+//	err := it.Err()
+type MissedFeedsIterator struct {
+	ml      *MeLi
+	topic   Topic
+	offset  int
+	total   int
+	started bool
+
+	page []*Webhook
+	cur  *Webhook
+	err  error
+}
+
+func (ml *MeLi) MissedFeeds(topic Topic) *MissedFeedsIterator {
+	return &MissedFeedsIterator{ml: ml, topic: topic}
+}
+
+// Next advances to the next notification, fetching the next page when needed.
+// It returns false once there're no more notifications or an err occurred
+func (it *MissedFeedsIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.page) == 0 {
+		if it.started && it.offset >= it.total {
+			return false
+		}
+		edge, err := it.ml.GetMissedFeeds(it.topic, it.offset, missedFeedsLimit)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+		it.total = edge.Total
+		it.offset += len(edge.Messages)
+		it.page = edge.Messages
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+func (it *MissedFeedsIterator) Webhook() *Webhook {
+	return it.cur
+}
+
+func (it *MissedFeedsIterator) Err() error {
+	return it.err
+}
+
+// RecoverMissedFeeds enqueues the missed notifications of the given topic (or every one if it's empty),
+// so they're processed as the ones received live. Meanwhile the queue is full, it waits for the workers to
+// free it instead of dropping the remaining ones. It returns the quantity of notifications enqueued, which
+// excludes the already processed ones
+func (h *WebhookHandler) RecoverMissedFeeds(topic Topic) (int, error) {
+	var recovered int
+	it := h.ml.MissedFeeds(topic)
+	for it.Next() {
+		enqueued, err := h.enqueue(it.Webhook())
+		for err == ErrQueueFull {
+			select {
+			case <-time.After(h.backoff):
+			case <-h.done:
+				return recovered, ErrClosedWebhookHandler
+			}
+			enqueued, err = h.enqueue(it.Webhook())
+		}
+		if err != nil {
+			return recovered, err
+		}
+		if enqueued {
+			recovered++
+		}
+	}
+	return recovered, it.Err()
+}
@@ -0,0 +1,173 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestWebhookHandler_RecoverMissedFeeds(t *testing.T) {
+	t.Parallel()
+	sent := time.Unix(1, 0).UTC()
+	params := url.Values{
+		"access_token": []string{"foo"},
+		"app_id":       []string{"123"},
+		"topic":        []string{"orders_v2"},
+		"offset":       []string{"0"},
+		"limit":        []string{"50"},
+	}
+	tests := []struct {
+		name          string
+		creds         *creds
+		stub          *httpstub.Stub
+		wantRecovered int
+		wantErr       error
+		want          []string
+	}{
+		{
+			name:    "NIL ACCESS TOKEN",
+			creds:   &creds{ApplicationId: "123"},
+			wantErr: ErrNilAccessToken,
+		},
+		{
+			name:  "REMOTE returns an ERR",
+			creds: &creds{ApplicationId: "123", Access: "foo"},
+			stub: &httpstub.Stub{Status: 400,
+				URL:     "/missed_feeds",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: params},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:  "REMOTE returns CORRECTly",
+			creds: &creds{ApplicationId: "123", Access: "foo"},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/missed_feeds",
+				Body: &MissedFeedsEdge{Total: 2, Limit: 50, Messages: []*Webhook{
+					{Resource: "/orders/1", Topic: TopicOrders, ApplicationId: 123, Sent: sent},
+					{Resource: "/orders/2", Topic: TopicOrders, ApplicationId: 123, Sent: sent},
+				}},
+				Receive: httpstub.Receive{Params: params},
+			},
+			wantRecovered: 2,
+			want:          []string{"/orders/1", "/orders/2"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: tt.creds}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			h := ml.NewWebhookHandlerWithOptions(&WebhookHandlerOptions{Workers: 1})
+			var lock sync.Mutex
+			var got []string
+			h.Handle(TopicOrders, func(wh *Webhook) error {
+				lock.Lock()
+				defer lock.Unlock()
+				got = append(got, wh.Resource)
+				return nil
+			})
+			recovered, err := h.RecoverMissedFeeds(TopicOrders)
+			h.Close()
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("WebhookHandler.RecoverMissedFeeds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if recovered != tt.wantRecovered {
+				t.Errorf("WebhookHandler.RecoverMissedFeeds() = %v, want %v", recovered, tt.wantRecovered)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("WebhookHandler.RecoverMissedFeeds() dispatched mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+// fullSignalQueue signals each time a push is rejected for being full
+type fullSignalQueue struct {
+	*MemoryWebhookQueue
+	full chan struct{}
+}
+
+func (q *fullSignalQueue) Push(wh *Webhook) error {
+	err := q.MemoryWebhookQueue.Push(wh)
+	if err == ErrQueueFull {
+		select {
+		case q.full <- struct{}{}:
+		default:
+		}
+	}
+	return err
+}
+
+func TestWebhookHandler_RecoverMissedFeeds_Pages(t *testing.T) {
+	t.Parallel()
+	sent := time.Unix(1, 0).UTC()
+	pages := map[string][]*Webhook{
+		"0": {
+			{Resource: "/orders/1", Topic: TopicOrders, ApplicationId: 123, Sent: sent},
+			{Resource: "/orders/2", Topic: TopicOrders, ApplicationId: 123, Sent: sent},
+		},
+		"2": {
+			{Resource: "/orders/3", Topic: TopicOrders, ApplicationId: 123, Sent: sent},
+			{Resource: "/orders/4", Topic: TopicOrders, ApplicationId: 123, Sent: sent},
+		},
+	}
+	ml := &MeLi{creds: &creds{ApplicationId: "123", Access: "foo"}}
+	var offsets []string
+	ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		offset := req.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		body, err := json.Marshal(&MissedFeedsEdge{Total: 4, Offset: 0, Limit: 50, Messages: pages[offset]})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+	})})
+
+	// The only worker is busy with the first notification until the queue gets full
+	queue := &fullSignalQueue{MemoryWebhookQueue: NewMemoryWebhookQueue(1), full: make(chan struct{}, 1)}
+	ledger := NewMemoryLedger(0)
+	if err := ledger.Mark(pages["0"][1].Key()); err != nil {
+		t.Fatalf("MemoryLedger.Mark() error = %v", err)
+	}
+	h := ml.NewWebhookHandlerWithOptions(&WebhookHandlerOptions{Workers: 1, Queue: queue, Ledger: ledger, Backoff: time.Millisecond})
+	var lock sync.Mutex
+	var got []string
+	h.Handle(TopicOrders, func(wh *Webhook) error {
+		if wh.Resource == "/orders/1" {
+			<-queue.full
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		got = append(got, wh.Resource)
+		return nil
+	})
+	recovered, err := h.RecoverMissedFeeds(TopicOrders)
+	h.Close()
+	if err != nil {
+		t.Errorf("WebhookHandler.RecoverMissedFeeds() error = %v, wantErr %v", err, nil)
+	}
+	if recovered != 3 {
+		t.Errorf("WebhookHandler.RecoverMissedFeeds() = %v, want %v", recovered, 3)
+	}
+	if diff := cmp.Diff([]string{"0", "2"}, offsets); diff != "" {
+		t.Errorf("MissedFeedsIterator offsets mismatch (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"/orders/1", "/orders/3", "/orders/4"}, got); diff != "" {
+		t.Errorf("WebhookHandler.RecoverMissedFeeds() dispatched mismatch (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,250 @@
+package meli
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+type OrderId int64
+
+type Order struct {
+	Id          OrderId     `json:"id,omitempty"`
+	Status      OrderStatus `json:"status,omitempty"`
+	DateCreated time.Time   `json:"date_created,omitempty"`
+	DateClosed  time.Time   `json:"date_closed,omitempty"`
+	LastUpdated time.Time   `json:"last_updated,omitempty"`
+
+	TotalAmount float64    `json:"total_amount,omitempty"`
+	PaidAmount  float64    `json:"paid_amount,omitempty"`
+	CurrencyId  CurrencyId `json:"currency_id,omitempty"`
+
+	OrderItems []*OrderItem    `json:"order_items,omitempty"`
+	Buyer      *OrderUser      `json:"buyer,omitempty"`
+	Seller     *OrderUser      `json:"seller,omitempty"`
+	Payments   []*Payment      `json:"payments,omitempty"`
+	Shipping   *OrderShipping  `json:"shipping,omitempty"`
+	Feedback   *OrderFeedbacks `json:"feedback,omitempty"`
+
+	Tags   []string `json:"tags,omitempty"`
+	PackId int64    `json:"pack_id,omitempty"`
+}
+
+type OrderStatus string
+
+const (
+	OrderConfirmed         OrderStatus = "confirmed"
+	OrderPaymentRequired   OrderStatus = "payment_required"
+	OrderPaymentInProcess  OrderStatus = "payment_in_process"
+	OrderPartiallyPaid     OrderStatus = "partially_paid"
+	OrderPaid              OrderStatus = "paid"
+	OrderPartiallyRefunded OrderStatus = "partially_refunded"
+	OrderPendingCancel     OrderStatus = "pending_cancel"
+	OrderCancelled         OrderStatus = "cancelled"
+	OrderInvalid           OrderStatus = "invalid"
+)
+
+type OrderItem struct {
+	Item          *OrderedProduct `json:"item,omitempty"`
+	Quantity      int             `json:"quantity,omitempty"`
+	UnitPrice     float64         `json:"unit_price,omitempty"`
+	FullUnitPrice float64         `json:"full_unit_price,omitempty"`
+	CurrencyId    CurrencyId      `json:"currency_id,omitempty"`
+	SaleFee       float64         `json:"sale_fee,omitempty"`
+	ListingTypeId ListingTypeId   `json:"listing_type_id,omitempty"`
+}
+
+// OrderedProduct is the snapshot of the product (and its variant, if any) at the moment of the sale
+type OrderedProduct struct {
+	Id                  ProductId    `json:"id,omitempty"`
+	Title               string       `json:"title,omitempty"`
+	CategoryId          CategoryId   `json:"category_id,omitempty"`
+	VariationId         VariantId    `json:"variation_id,omitempty"`
+	VariationAttributes []*Attribute `json:"variation_attributes,omitempty"`
+	SellerSku           string       `json:"seller_sku,omitempty"`
+	SellerCustomField   string       `json:"seller_custom_field,omitempty"`
+	Warranty            string       `json:"warranty,omitempty"`
+	Condition           Condition    `json:"condition,omitempty"`
+}
+
+type OrderUser struct {
+	Id        int    `json:"id,omitempty"`
+	Nickname  string `json:"nickname,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+type Payment struct {
+	Id                int64      `json:"id,omitempty"`
+	OrderId           OrderId    `json:"order_id,omitempty"`
+	PayerId           int        `json:"payer_id,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	StatusDetail      string     `json:"status_detail,omitempty"`
+	TransactionAmount float64    `json:"transaction_amount,omitempty"`
+	TotalPaidAmount   float64    `json:"total_paid_amount,omitempty"`
+	ShippingCost      float64    `json:"shipping_cost,omitempty"`
+	CurrencyId        CurrencyId `json:"currency_id,omitempty"`
+	PaymentMethodId   string     `json:"payment_method_id,omitempty"`
+	PaymentType       string     `json:"payment_type,omitempty"`
+	Installments      int        `json:"installments,omitempty"`
+	DateCreated       time.Time  `json:"date_created,omitempty"`
+	DateApproved      time.Time  `json:"date_approved,omitempty"`
+	DateLastModified  time.Time  `json:"date_last_modified,omitempty"`
+}
+
+type OrderShipping struct {
+	Id int64 `json:"id,omitempty"`
+}
+
+type OrderFeedbacks struct {
+	Buyer  *OrderFeedback `json:"buyer,omitempty"`
+	Seller *OrderFeedback `json:"seller,omitempty"`
+}
+
+type OrderFeedback struct {
+	Id     int64  `json:"id,omitempty"`
+	Rating string `json:"rating,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// HasTag reports if the order is tagged with the given tag (e.g. "delivered", "not_delivered", "paid")
+func (ord *Order) HasTag(tag string) bool {
+	for _, ordTag := range ord.Tags {
+		if ordTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (ml *MeLi) GetOrder(id OrderId) (*Order, error) {
+	if id == 0 {
+		return nil, ErrNilOrderId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/orders/%v", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	ord := &Order{}
+	err = json.NewDecoder(resp.Body).Decode(ord)
+	if err != nil {
+		return nil, err
+	}
+	return ord, nil
+}
+
+type OrderSearch struct {
+	SellerId int
+	Status   OrderStatus
+	DateFrom time.Time // Filters by date_created
+	DateTo   time.Time
+	Sort     string // e.g. date_asc, date_desc
+	Offset   int
+	Limit    int
+}
+
+type OrderEdge struct {
+	Query   string   `json:"query,omitempty"`
+	Results []*Order `json:"results"`
+	Sort    struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"sort"`
+	Paging Paging `json:"paging"`
+}
+
+const orderDateLayout = "2006-01-02T15:04:05.000-07:00"
+
+// SearchOrders retrieves a page of the orders of the seller matching the given search
+func (ml *MeLi) SearchOrders(search *OrderSearch) (*OrderEdge, error) {
+	if search == nil || search.SellerId == 0 {
+		return nil, ErrNilSellerId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("seller", strconv.Itoa(search.SellerId))
+	if search.Status != "" {
+		params.Set("order.status", string(search.Status))
+	}
+	if !search.DateFrom.IsZero() {
+		params.Set("order.date_created.from", search.DateFrom.Format(orderDateLayout))
+	}
+	if !search.DateTo.IsZero() {
+		params.Set("order.date_created.to", search.DateTo.Format(orderDateLayout))
+	}
+	if search.Sort != "" {
+		params.Set("sort", search.Sort)
+	}
+	if search.Offset > 0 {
+		params.Set("offset", strconv.Itoa(search.Offset))
+	}
+	if search.Limit > 0 {
+		params.Set("limit", strconv.Itoa(search.Limit))
+	}
+	URL, err := ml.RouteTo("/orders/search", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &OrderEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+// SearchAllOrders walks every page of the given search, starting from its offset
+func (ml *MeLi) SearchAllOrders(search *OrderSearch) ([]*Order, error) {
+	if search == nil {
+		return nil, ErrNilSellerId
+	}
+	page := *search
+	var ords []*Order
+	for {
+		edge, err := ml.SearchOrders(&page)
+		if err != nil {
+			return nil, err
+		}
+		ords = append(ords, edge.Results...)
+		page.Offset += len(edge.Results)
+		if len(edge.Results) == 0 || page.Offset >= edge.Paging.Total {
+			break
+		}
+	}
+	return ords, nil
+}
+
+func (ml *MeLi) ProcessOrderWebhook(wh *Webhook) (*Order, error) {
+	id, err := wh.resourceIdOf(ResourceOrders)
+	if err != nil {
+		return nil, err
+	}
+	ordId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidResource
+	}
+	return ml.GetOrder(OrderId(ordId))
+}
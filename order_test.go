@@ -0,0 +1,134 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_ProcessOrderWebhook(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		creds   *creds
+		wh      *Webhook
+		stub    *httpstub.Stub
+		wantOrd *Order
+		wantErr error
+	}{
+		{
+			name:    "NOT an ORDER resource",
+			creds:   &creds{Access: "foo"},
+			wh:      &Webhook{Resource: "/items/MLA1", Topic: TopicOrders},
+			wantErr: ErrInvalidResource,
+		},
+		{
+			name:    "NIL CREDENTIALS",
+			creds:   &creds{},
+			wh:      &Webhook{Resource: "/orders/1", Topic: TopicOrders},
+			wantErr: ErrNilAccessToken,
+		},
+		{
+			name:  "REMOTE returns an ERR",
+			creds: &creds{Access: "foo"},
+			wh:    &Webhook{Resource: "/orders/1", Topic: TopicOrders},
+			stub: &httpstub.Stub{Status: 404,
+				URL:     "/orders/1",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:  "REMOTE returns CORRECTly",
+			creds: &creds{Access: "foo"},
+			wh:    &Webhook{Resource: "/orders/1", Topic: TopicOrders},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/orders/1",
+				Body: &Order{Id: 1, Status: OrderPaid, PackId: 2,
+					OrderItems: []*OrderItem{{Item: &OrderedProduct{Id: "MLA1", VariationId: 3}, Quantity: 2}},
+				},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantOrd: &Order{Id: 1, Status: OrderPaid, PackId: 2,
+				OrderItems: []*OrderItem{{Item: &OrderedProduct{Id: "MLA1", VariationId: 3}, Quantity: 2}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: tt.creds}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotOrd, err := ml.ProcessOrderWebhook(tt.wh)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ProcessOrderWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantOrd, gotOrd); diff != "" {
+				t.Errorf("MeLi.ProcessOrderWebhook() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_SearchAllOrders(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		search   *OrderSearch
+		stub     *httpstub.Stub
+		wantOrds []*Order
+		wantErr  error
+	}{
+		{
+			name:    "NIL SELLER",
+			search:  &OrderSearch{Status: OrderPaid},
+			wantErr: ErrNilSellerId,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			search: &OrderSearch{SellerId: 10, Status: OrderPaid, DateFrom: from},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/orders/search",
+				Body: &OrderEdge{
+					Results: []*Order{{Id: 1}, {Id: 2}},
+					Paging:  Paging{Total: 2, Limit: 50},
+				},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token":            []string{"foo"},
+					"seller":                  []string{"10"},
+					"order.status":            []string{"paid"},
+					"order.date_created.from": []string{"2019-07-01T00:00:00.000+00:00"},
+				}},
+			},
+			wantOrds: []*Order{{Id: 1}, {Id: 2}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotOrds, err := ml.SearchAllOrders(tt.search)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.SearchAllOrders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantOrds, gotOrds); diff != "" {
+				t.Errorf("MeLi.SearchAllOrders() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/url"
+	"sort"
+	"strconv"
 )
 
 type Variation struct {
@@ -74,3 +76,165 @@ func (ml *MeLi) ClassifyBatch(titles []string, siteId SiteId) ([]*Category, erro
 	}
 	return cats, nil
 }
+
+// DomainCandidate is each result of the domain discovery of a query
+type DomainCandidate struct {
+	DomainId     string       `json:"domain_id,omitempty"`
+	DomainName   string       `json:"domain_name,omitempty"`
+	CategoryId   CategoryId   `json:"category_id,omitempty"`
+	CategoryName string       `json:"category_name,omitempty"`
+	Attributes   []*Attribute `json:"attributes,omitempty"`
+}
+
+func (ml *MeLi) DiscoverDomains(q string, siteId SiteId, limit int) ([]*DomainCandidate, error) {
+	params := url.Values{}
+	params.Set("q", q)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	URL, err := ml.RouteTo("/sites/%v/domain_discovery/search", params, siteId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	candidates := []*DomainCandidate{}
+	err = json.NewDecoder(resp.Body).Decode(&candidates)
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+type PredictionConfig struct {
+	// MinConfidence is the minimum probability of the predictor to accept its category
+	MinConfidence float64
+	// TopN is the max quantity of candidates retrieved (defaults to 1). The domain discovery is consulted
+	// for the candidates the predictor lacks
+	TopN int
+	// Fallback accepts the first candidate of the domain discovery when the predictor is not confident enough
+	Fallback bool
+	// ReviewFallbacks flags for review the titles categorized by the fallback when the predictor retrieved no
+	// category at all. The ones whose predicted category was below MinConfidence are always flagged
+	ReviewFallbacks bool
+}
+
+type Prediction struct {
+	Title string `json:"title,omitempty"`
+	// Category is the accepted category, being nil in case of none being acceptable
+	Category *Category `json:"category,omitempty"`
+	// Candidates are sorted by its probability
+	Candidates  []*Category `json:"candidates,omitempty"`
+	NeedsReview bool        `json:"needs_review,omitempty"`
+	// Err is the err produced while classifying the title, which is flagged for review without being resolved
+	Err error `json:"-"`
+}
+
+// Predict categorizes the given title, only accepting the predicted category if it's confident enough
+// (falling back to the domain discovery when configured)
+func (ml *MeLi) Predict(title string, siteId SiteId, cfg *PredictionConfig) (*Prediction, error) {
+	cat, err := ml.Classify(title, siteId)
+	if err != nil {
+		return nil, err
+	}
+	return ml.resolvePrediction(title, cat, siteId, cfg)
+}
+
+// PredictBatch is the same as Predict but classifying the titles by batches (see ClassifyAll).
+// The titles which couldn't be classified keep its err, so outages aren't confused with unknown categories
+func (ml *MeLi) PredictBatch(titles []string, siteId SiteId, cfg *PredictionConfig) ([]*Prediction, error) {
+	cls, err := ml.ClassifyAll(titles, siteId, nil)
+	if err != nil {
+		return nil, err
+	}
+	var preds []*Prediction
+	for _, cl := range cls {
+		if cl.Err != nil && cl.Err != ErrNilCategory {
+			preds = append(preds, &Prediction{Title: cl.Title, NeedsReview: true, Err: cl.Err})
+			continue
+		}
+		pred, err := ml.resolvePrediction(cl.Title, cl.Category, siteId, cfg)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	return preds, nil
+}
+
+func (ml *MeLi) resolvePrediction(title string, cat *Category, siteId SiteId, cfg *PredictionConfig) (*Prediction, error) {
+	if cfg == nil {
+		cfg = &PredictionConfig{}
+	}
+	topN := cfg.TopN
+	if topN <= 0 {
+		topN = 1
+	}
+	pred := &Prediction{Title: title}
+	var belowConfidence bool
+	if cat != nil && cat.Id != "" {
+		pred.Candidates = append(pred.Candidates, cat)
+		if cat.PredictionProbability >= cfg.MinConfidence {
+			pred.Category = cat
+		} else {
+			belowConfidence = true
+		}
+	}
+	if cfg.TopN > len(pred.Candidates) || (cfg.Fallback && pred.Category == nil) {
+		domains, err := ml.DiscoverDomains(title, siteId, topN)
+		if err != nil {
+			return nil, err
+		}
+		var fallback *Category
+		for _, domain := range domains {
+			candidate := pred.candidate(domain.CategoryId)
+			if candidate == nil {
+				candidate = &Category{Id: domain.CategoryId, Name: domain.CategoryName}
+				pred.Candidates = append(pred.Candidates, candidate)
+			}
+			if fallback == nil {
+				fallback = candidate
+			}
+		}
+		if cfg.Fallback && pred.Category == nil && fallback != nil {
+			pred.Category = fallback
+			pred.NeedsReview = belowConfidence || cfg.ReviewFallbacks
+		}
+	}
+	sort.SliceStable(pred.Candidates, func(i, j int) bool {
+		return pred.Candidates[i].PredictionProbability > pred.Candidates[j].PredictionProbability
+	})
+	if len(pred.Candidates) > topN {
+		pred.Candidates = pred.Candidates[:topN]
+	}
+	if pred.Category == nil {
+		pred.NeedsReview = true
+	}
+	return pred, nil
+}
+
+func (pred *Prediction) candidate(catId CategoryId) *Category {
+	for _, cat := range pred.Candidates {
+		if cat.Id == catId {
+			return cat
+		}
+	}
+	return nil
+}
+
+// ReviewTitles retrieves the titles of the predictions which need a human review
+func ReviewTitles(preds []*Prediction) []string {
+	var titles []string
+	for _, pred := range preds {
+		if pred.NeedsReview {
+			titles = append(titles, pred.Title)
+		}
+	}
+	return titles
+}
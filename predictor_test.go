@@ -143,3 +143,188 @@ func TestMeLi_ClassifyBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestMeLi_Predict(t *testing.T) {
+	t.Parallel()
+	predictStub := func(prob float64) *httpstub.Stub {
+		return &httpstub.Stub{Status: 200,
+			URL:  "/sites/MLA/categories/category_predictor/predict",
+			Body: &Category{Id: "foo", PredictionProbability: prob, Name: "bar"},
+			Receive: httpstub.Receive{
+				Params: url.Values{"title": []string{"quux"}},
+			},
+		}
+	}
+	discoveryStub := &httpstub.Stub{Status: 200,
+		URL: "/sites/MLA/domain_discovery/search",
+		Body: []*DomainCandidate{
+			{DomainId: "MLA-BAZ", CategoryId: "baz", CategoryName: "qux"},
+			{DomainId: "MLA-FOO", CategoryId: "foo", CategoryName: "bar"},
+		},
+		Receive: httpstub.Receive{
+			Params: url.Values{"q": []string{"quux"}, "limit": []string{"2"}},
+		},
+	}
+	tests := []struct {
+		name     string
+		cfg      *PredictionConfig
+		stubs    []*httpstub.Stub
+		wantPred *Prediction
+		wantErr  error
+	}{
+		{
+			name:  "predictor is CONFIDENT",
+			cfg:   &PredictionConfig{MinConfidence: 0.8, Fallback: true},
+			stubs: []*httpstub.Stub{predictStub(0.9)},
+			wantPred: &Prediction{Title: "quux",
+				Category:   &Category{Id: "foo", PredictionProbability: 0.9, Name: "bar"},
+				Candidates: []*Category{{Id: "foo", PredictionProbability: 0.9, Name: "bar"}},
+			},
+		},
+		{
+			name:  "predictor is NOT CONFIDENT and there's NO FALLBACK",
+			cfg:   &PredictionConfig{MinConfidence: 0.8},
+			stubs: []*httpstub.Stub{predictStub(0.3)},
+			wantPred: &Prediction{Title: "quux",
+				Candidates:  []*Category{{Id: "foo", PredictionProbability: 0.3, Name: "bar"}},
+				NeedsReview: true,
+			},
+		},
+		{
+			name:  "predictor is NOT CONFIDENT and FALLS BACK to domain discovery",
+			cfg:   &PredictionConfig{MinConfidence: 0.8, TopN: 2, Fallback: true, ReviewFallbacks: true},
+			stubs: []*httpstub.Stub{predictStub(0.3), discoveryStub},
+			wantPred: &Prediction{Title: "quux",
+				Category: &Category{Id: "baz", Name: "qux"},
+				Candidates: []*Category{
+					{Id: "foo", PredictionProbability: 0.3, Name: "bar"},
+					{Id: "baz", Name: "qux"},
+				},
+				NeedsReview: true,
+			},
+		},
+		{
+			name:  "predictor is NOT CONFIDENT and the FALLBACK is REVIEWED even if not asked",
+			cfg:   &PredictionConfig{MinConfidence: 0.8, TopN: 2, Fallback: true},
+			stubs: []*httpstub.Stub{predictStub(0.3), discoveryStub},
+			wantPred: &Prediction{Title: "quux",
+				Category: &Category{Id: "baz", Name: "qux"},
+				Candidates: []*Category{
+					{Id: "foo", PredictionProbability: 0.3, Name: "bar"},
+					{Id: "baz", Name: "qux"},
+				},
+				NeedsReview: true,
+			},
+		},
+		{
+			name:  "predictor is CONFIDENT and TOP N is completed WITHOUT FALLBACK",
+			cfg:   &PredictionConfig{MinConfidence: 0.8, TopN: 2},
+			stubs: []*httpstub.Stub{predictStub(0.9), discoveryStub},
+			wantPred: &Prediction{Title: "quux",
+				Category: &Category{Id: "foo", PredictionProbability: 0.9, Name: "bar"},
+				Candidates: []*Category{
+					{Id: "foo", PredictionProbability: 0.9, Name: "bar"},
+					{Id: "baz", Name: "qux"},
+				},
+			},
+		},
+		{
+			name:  "predictor is NOT CONFIDENT and TOP N WITHOUT FALLBACK accepts NO category",
+			cfg:   &PredictionConfig{MinConfidence: 0.8, TopN: 2},
+			stubs: []*httpstub.Stub{predictStub(0.3), discoveryStub},
+			wantPred: &Prediction{Title: "quux",
+				Candidates: []*Category{
+					{Id: "foo", PredictionProbability: 0.3, Name: "bar"},
+					{Id: "baz", Name: "qux"},
+				},
+				NeedsReview: true,
+			},
+		},
+		{
+			name: "REMOTE returns an ERR",
+			cfg:  &PredictionConfig{MinConfidence: 0.8},
+			stubs: []*httpstub.Stub{{Status: 400,
+				URL:     "/sites/MLA/categories/category_predictor/predict",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"title": []string{"quux"}}},
+			}},
+			wantErr: svErrFooBar,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: tt.stubs, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotPred, err := ml.Predict("quux", "MLA", tt.cfg)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.Predict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantPred, gotPred); diff != "" {
+				t.Errorf("MeLi.Predict() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_PredictBatch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		stub      *httpstub.Stub
+		wantPreds []*Prediction
+	}{
+		{
+			name: "titles WITHOUT category are resolved",
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/sites/MLA/categories/category_predictor/predict",
+				Body: []*Category{{Id: "foo", PredictionProbability: 0.9}, {}},
+				Receive: httpstub.Receive{
+					Body: JSONMarshal(t, []map[string]string{{"title": "a"}, {"title": "b"}}),
+				},
+			},
+			wantPreds: []*Prediction{
+				{Title: "a", Category: &Category{Id: "foo", PredictionProbability: 0.9},
+					Candidates: []*Category{{Id: "foo", PredictionProbability: 0.9}}},
+				{Title: "b", NeedsReview: true},
+			},
+		},
+		{
+			name: "REMOTE returns an ERR which is KEPT on each prediction",
+			stub: &httpstub.Stub{Status: 503,
+				URL:  "/sites/MLA/categories/category_predictor/predict",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{
+					Body: JSONMarshal(t, []map[string]string{{"title": "a"}, {"title": "b"}}),
+				},
+			},
+			wantPreds: []*Prediction{
+				{Title: "a", NeedsReview: true, Err: svErrFooBar},
+				{Title: "b", NeedsReview: true, Err: svErrFooBar},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotPreds, err := ml.PredictBatch([]string{"a", "b"}, "MLA", &PredictionConfig{MinConfidence: 0.8})
+			if err != nil {
+				t.Errorf("MeLi.PredictBatch() error = %v, wantErr %v", err, nil)
+			}
+			errComparer := cmp.Comparer(func(x, y error) bool { return fmt.Sprintf("%v", x) == fmt.Sprintf("%v", y) })
+			if diff := cmp.Diff(tt.wantPreds, gotPreds, errComparer); diff != "" {
+				t.Errorf("MeLi.PredictBatch() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -12,16 +12,16 @@ type ProductId string
 
 type Product struct {
 	Id              ProductId  `json:"id,omitempty"`
-	SiteId          string     `json:"site_id,omitempty"`
+	SiteId          SiteId     `json:"site_id,omitempty"`
 	Title           string     `json:"title,omitempty"`
 	Status          string     `json:"status,omitempty"`
 	SellerId        int        `json:"seller_id,omitempty"`
 	CategoryId      CategoryId `json:"category_id,omitempty"`
 	OfficialStoreId int        `json:"official_store_id,omitempty"`
 
-	Price      float64 `json:"price,omitempty"`
-	BasePrice  float64 `json:"base_price,omitempty"`
-	CurrencyId string  `json:"currency_id,omitempty"`
+	Price      float64    `json:"price,omitempty"`
+	BasePrice  float64    `json:"base_price,omitempty"`
+	CurrencyId CurrencyId `json:"currency_id,omitempty"`
 
 	AvailableQuantity *int `json:"available_quantity,omitempty"`
 	InitialQuantity   int  `json:"initial_quantity,omitempty"`
@@ -70,19 +70,19 @@ type Product struct {
 	DomainId        string   `json:"domain_id,omitempty"`
 	AutomaticRelist bool     `json:"automatic_relist,omitempty"`
 
-	Health                       float64       `json:"health,omitempty"`
-	Location                     struct{}      `json:"location,omitempty"`
-	VideoId                      string        `json:"video_id,omitempty"`
-	SubStatus                    []interface{} `json:"sub_status,omitempty"`
-	CatalogProductId             string        `json:"catalog_product_id,omitempty"`
-	ParentItemId                 string        `json:"parent_item_id,omitempty"`
-	DifferentialPricing          interface{}   `json:"differential_pricing,omitempty"`
-	DealIds                      []interface{} `json:"deal_ids,omitempty"`
-	CoverageAreas                []interface{} `json:"coverage_areas,omitempty"`
-	Warnings                     []interface{} `json:"warnings,omitempty"`
-	OriginalPrice                float64       `json:"original_price,omitempty"`
-	NonMercadoPagoPaymentMethods []interface{} `json:"non_mercado_pago_payment_methods,omitempty"`
-	Subtitle                     string        `json:"subtitle,omitempty"`
+	Health                       float64          `json:"health,omitempty"`
+	Location                     struct{}         `json:"location,omitempty"`
+	VideoId                      string           `json:"video_id,omitempty"`
+	SubStatus                    []SubStatus      `json:"sub_status,omitempty"`
+	CatalogProductId             CatalogProductId `json:"catalog_product_id,omitempty"`
+	ParentItemId                 string           `json:"parent_item_id,omitempty"`
+	DifferentialPricing          interface{}      `json:"differential_pricing,omitempty"`
+	DealIds                      []PromotionId    `json:"deal_ids,omitempty"`
+	CoverageAreas                []interface{}    `json:"coverage_areas,omitempty"`
+	Warnings                     []*Warning       `json:"warnings,omitempty"`
+	OriginalPrice                float64          `json:"original_price,omitempty"`
+	NonMercadoPagoPaymentMethods []interface{}    `json:"non_mercado_pago_payment_methods,omitempty"`
+	Subtitle                     string           `json:"subtitle,omitempty"`
 
 	Deleted bool `json:"deleted,omitempty"`
 
@@ -108,6 +108,33 @@ type BuyingMode string
 
 type ListingTypeId string
 
+type ListingType struct {
+	SiteId SiteId        `json:"site_id,omitempty"`
+	Id     ListingTypeId `json:"id,omitempty"`
+	Name   string        `json:"name,omitempty"`
+}
+
+// ListingTypes retrieves the listing types available on the given site
+func (ml *MeLi) ListingTypes(siteId SiteId) ([]*ListingType, error) {
+	if siteId == "" {
+		return nil, ErrNilSiteId
+	}
+	URL, err := ml.RouteTo("/sites/%v/listing_types", nil, siteId)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ml.getCached(URL)
+	if err != nil {
+		return nil, err
+	}
+	lts := []*ListingType{}
+	err = json.Unmarshal(body, &lts)
+	if err != nil {
+		return nil, err
+	}
+	return lts, nil
+}
+
 // TODO: test with the api responses of https://api.mercadolibre.com/sites/{Site_id}/listing_types
 func (ltId ListingTypeId) validate(siteId SiteId) error {
 	validListingTypeIds := map[SiteId][]ListingTypeId{
@@ -217,6 +244,9 @@ func (prod *Product) validate(exists bool) error {
 }
 
 func (prod *Product) site() SiteId {
+	if prod.SiteId != "" {
+		return prod.SiteId
+	}
 	if len(prod.CategoryId) < 3 {
 		return ""
 	}
@@ -243,8 +273,13 @@ type ProductEdge struct {
 	} `json:"available_orders"`
 }
 
+// FetchProducts retrieves the active listings of the authenticated seller
 func (ml *MeLi) FetchProducts() ([]*Product, error) {
-	prodIds, err := ml.ScanAllProducts()
+	me, err := ml.GetMe()
+	if err != nil {
+		return nil, err
+	}
+	prodIds, err := ml.ScanAllProducts(me.Id, "active")
 	if err != nil {
 		return nil, err
 	}
@@ -305,11 +340,13 @@ func (ml *MeLi) GetProducts(ids []ProductId) ([]*Product, error) {
 	return prods, nil
 }
 
-func (ml *MeLi) ScanAllProducts() ([]ProductId, error) {
+// ScanAllProducts retrieves the ids of every listing of the seller with the given status (whichever it is if empty).
+// It scans the listings instead of paging them, so it isn't capped by the max offset of the remote
+func (ml *MeLi) ScanAllProducts(sellerId int, status string) ([]ProductId, error) {
 	var scrollId string
 	var prodIds []ProductId
 	for {
-		edge, err := ml.ScanProducts(scrollId)
+		edge, err := ml.ScanProducts(sellerId, status, scrollId)
 		if err != nil {
 			return nil, err
 		}
@@ -317,21 +354,31 @@ func (ml *MeLi) ScanAllProducts() ([]ProductId, error) {
 			break
 		}
 		prodIds = append(prodIds, edge.Results...)
+		scrollId = edge.ScrollId
 	}
 	return prodIds, nil
 }
 
-func (ml *MeLi) ScanProducts(scrollId string) (*ProductEdge, error) {
+// ScanProducts retrieves the page of the scan of the listings of the seller following the given scroll
+// (the first one if it's empty). Its ScrollId is the one of the next page
+func (ml *MeLi) ScanProducts(sellerId int, status, scrollId string) (*ProductEdge, error) {
+	if sellerId == 0 {
+		return nil, ErrNilSellerId
+	}
 	params, err := ml.paramsWithToken()
 	if err != nil {
 		return nil, err
 	}
 
-	params.Set("scroll_id", scrollId)
-	params.Set("status", "active")
+	if scrollId != "" {
+		params.Set("scroll_id", scrollId)
+	}
+	if status != "" {
+		params.Set("status", status)
+	}
 	params.Set("limit", "100")
 	params.Set("search_type", "scan")
-	URL, err := ml.RouteTo("/users/%v/items/search", params)
+	URL, err := ml.RouteTo("/users/%v/items/search", params, sellerId)
 	if err != nil {
 		return nil, err
 	}
@@ -466,6 +513,32 @@ func (ml *MeLi) updateProduct(prod *Product) (*Product, error) {
 	return newProd, nil
 }
 
+// updateProductFields only puts the given fields onto the product, so the ones left aside aren't overwritten
+// (as the zero values SetProduct sends for every non-omitted field of the Product)
+func (ml *MeLi) updateProductFields(prodId ProductId, fields map[string]interface{}) error {
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return err
+	}
+	URL, err := ml.RouteTo("/items/%v", params, prodId)
+	if err != nil {
+		return err
+	}
+	jsonFields, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Put(URL, bytes.NewReader(jsonFields))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
 // ManageStock adds to the product's stock the given stock.
 // In case of giving a negative number, it rests the stock
 func (p *Product) ManageStock(stock int) {
@@ -64,6 +64,74 @@ func TestMeLi_GetProduct(t *testing.T) {
 	}
 }
 
+func TestMeLi_ScanProducts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		sellerId int
+		status   string
+		scrollId string
+		stub     *httpstub.Stub
+		want     *ProductEdge
+		wantErr  error
+	}{
+		{
+			name:    "NIL SELLER",
+			wantErr: ErrNilSellerId,
+		},
+		{
+			name:     "FIRST page of ANY status",
+			sellerId: 1,
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/users/1/items/search",
+				Body: &ProductEdge{Results: []ProductId{"MLA1"}, ScrollId: "bar"},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"},
+					"limit":        []string{"100"},
+					"search_type":  []string{"scan"},
+				}},
+			},
+			want: &ProductEdge{Results: []ProductId{"MLA1"}, ScrollId: "bar"},
+		},
+		{
+			name:     "NEXT page of the ACTIVE ones",
+			sellerId: 1,
+			status:   "active",
+			scrollId: "bar",
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/users/1/items/search",
+				Body: &ProductEdge{Results: []ProductId{"MLA2"}, ScrollId: "baz"},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"},
+					"limit":        []string{"100"},
+					"search_type":  []string{"scan"},
+					"scroll_id":    []string{"bar"},
+					"status":       []string{"active"},
+				}},
+			},
+			want: &ProductEdge{Results: []ProductId{"MLA2"}, ScrollId: "baz"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			got, err := ml.ScanProducts(tt.sellerId, tt.status, tt.scrollId)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ScanProducts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MeLi.ScanProducts() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestMeLi_SetProduct(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
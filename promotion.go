@@ -0,0 +1,317 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// promotionsAppVersion is the version of the seller promotions API the client speaks
+const promotionsAppVersion = "v2"
+
+type PromotionId string
+
+type PromotionType string
+
+const (
+	PromotionDeal                PromotionType = "DEAL"
+	PromotionPriceDiscount       PromotionType = "PRICE_DISCOUNT"
+	PromotionMarketplaceCampaign PromotionType = "MARKETPLACE_CAMPAIGN"
+	PromotionSellerCampaign      PromotionType = "SELLER_CAMPAIGN"
+	PromotionLightning           PromotionType = "LIGHTNING"
+	PromotionDealOfTheDay        PromotionType = "DOD"
+	PromotionVolume              PromotionType = "VOLUME"
+)
+
+type PromotionStatus string
+
+const (
+	// PromotionCandidate is the status of the promotions an item is eligible for, but isn't enrolled yet
+	PromotionCandidate PromotionStatus = "candidate"
+	PromotionPending   PromotionStatus = "pending"
+	PromotionStarted   PromotionStatus = "started"
+	PromotionFinished  PromotionStatus = "finished"
+)
+
+// Promotion is a campaign of the seller or, when retrieved by item, the participation of the item on it
+type Promotion struct {
+	Id           PromotionId     `json:"id,omitempty"`
+	Type         PromotionType   `json:"type"`
+	Status       PromotionStatus `json:"status,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	StartDate    time.Time       `json:"start_date,omitempty"`
+	FinishDate   time.Time       `json:"finish_date,omitempty"`
+	DeadlineDate time.Time       `json:"deadline_date,omitempty"`
+
+	Price         float64 `json:"price,omitempty"`
+	OriginalPrice float64 `json:"original_price,omitempty"`
+}
+
+type PromotionEdge struct {
+	Results []*Promotion `json:"results"`
+	Paging  Paging       `json:"paging"`
+}
+
+// SellerPromotions retrieves the campaigns the seller is invited to or created
+func (ml *MeLi) SellerPromotions(sellerId int) (*PromotionEdge, error) {
+	if sellerId == 0 {
+		return nil, ErrNilSellerId
+	}
+	params, err := ml.promotionsParams()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/seller-promotions/users/%v", params, sellerId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &PromotionEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+// ItemPromotions retrieves the promotions the item participates in or is a candidate for
+func (ml *MeLi) ItemPromotions(itemId ProductId) ([]*Promotion, error) {
+	if itemId == "" {
+		return nil, ErrNilProductId
+	}
+	params, err := ml.promotionsParams()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/seller-promotions/items/%v", params, itemId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	var promos []*Promotion
+	err = json.NewDecoder(resp.Body).Decode(&promos)
+	if err != nil {
+		return nil, err
+	}
+	return promos, nil
+}
+
+// IsItemEligible reports if the item is a candidate for the given promotion
+func (ml *MeLi) IsItemEligible(itemId ProductId, promoId PromotionId) (bool, error) {
+	if promoId == "" {
+		return false, ErrNilPromotionId
+	}
+	promos, err := ml.ItemPromotions(itemId)
+	if err != nil {
+		return false, err
+	}
+	for _, promo := range promos {
+		if promo.Id == promoId && promo.Status == PromotionCandidate {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PromotionOffer enrolls an item on a promotion. Discounts created by the seller (PRICE_DISCOUNT) have no
+// promotion id but need its dates, meanwhile the rest of the types need the id of the campaign
+type PromotionOffer struct {
+	PromotionId   PromotionId   `json:"promotion_id,omitempty"`
+	PromotionType PromotionType `json:"promotion_type"`
+	DealPrice     float64       `json:"deal_price"`
+	TopDealPrice  float64       `json:"top_deal_price,omitempty"` // Price for loyalty buyers
+	StartDate     *time.Time    `json:"start_date,omitempty"`
+	FinishDate    *time.Time    `json:"finish_date,omitempty"`
+}
+
+func (offer *PromotionOffer) validate() error {
+	if offer.PromotionType == "" {
+		return ErrNilPromotionType
+	}
+	if offer.DealPrice <= 0 {
+		return ErrNilDealPrice
+	}
+	if offer.PromotionType != PromotionPriceDiscount {
+		if offer.PromotionId == "" {
+			return ErrNilPromotionId
+		}
+		return nil
+	}
+	if offer.StartDate == nil || offer.FinishDate == nil || !offer.StartDate.Before(*offer.FinishDate) {
+		return ErrInvalidPromotionDates
+	}
+	return nil
+}
+
+func (ml *MeLi) AddItemToPromotion(itemId ProductId, offer *PromotionOffer) error {
+	if itemId == "" {
+		return ErrNilProductId
+	}
+	if offer == nil {
+		return ErrNilPromotionType
+	}
+	err := offer.validate()
+	if err != nil {
+		return err
+	}
+	params, err := ml.promotionsParams()
+	if err != nil {
+		return err
+	}
+	URL, err := ml.RouteTo("/seller-promotions/items/%v", params, itemId)
+	if err != nil {
+		return err
+	}
+	reqBody, err := json.Marshal(offer)
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Post(URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
+// RemoveItemFromPromotion ends the participation of the item on the promotion (whose id isn't needed for
+// PRICE_DISCOUNT ones)
+func (ml *MeLi) RemoveItemFromPromotion(itemId ProductId, promoType PromotionType, promoId PromotionId) error {
+	if itemId == "" {
+		return ErrNilProductId
+	}
+	if promoType == "" {
+		return ErrNilPromotionType
+	}
+	if promoType != PromotionPriceDiscount && promoId == "" {
+		return ErrNilPromotionId
+	}
+	params, err := ml.promotionsParams()
+	if err != nil {
+		return err
+	}
+	params.Set("promotion_type", string(promoType))
+	if promoId != "" {
+		params.Set("promotion_id", string(promoId))
+	}
+	URL, err := ml.RouteTo("/seller-promotions/items/%v", params, itemId)
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Delete(URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
+func (ml *MeLi) promotionsParams() (url.Values, error) {
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("app_version", promotionsAppVersion)
+	return params, nil
+}
+
+// ItemPrices are the prices an item is sold at, depending on the channel and the promotions it participates in
+type ItemPrices struct {
+	Id     ProductId    `json:"id"`
+	Prices []*ItemPrice `json:"prices"`
+}
+
+type ItemPrice struct {
+	Id            string     `json:"id"`
+	Type          string     `json:"type"` // e.g. standard, promotion
+	Amount        float64    `json:"amount"`
+	RegularAmount float64    `json:"regular_amount,omitempty"`
+	CurrencyId    CurrencyId `json:"currency_id"`
+	LastUpdated   time.Time  `json:"last_updated,omitempty"`
+	Conditions    struct {
+		ContextRestrictions []string   `json:"context_restrictions,omitempty"`
+		StartTime           *time.Time `json:"start_time,omitempty"`
+		EndTime             *time.Time `json:"end_time,omitempty"`
+	} `json:"conditions"`
+	Metadata struct {
+		PromotionId   PromotionId   `json:"promotion_id,omitempty"`
+		PromotionType PromotionType `json:"promotion_type,omitempty"`
+	} `json:"metadata"`
+}
+
+// Active retrieves the price a buyer without restrictions (e.g. loyalty level) pays at the given time:
+// the lowest of the applicable ones, or nil if there's none
+func (prices *ItemPrices) Active(at time.Time) *ItemPrice {
+	var active *ItemPrice
+	for _, price := range prices.Prices {
+		if !price.appliesAt(at) {
+			continue
+		}
+		if active == nil || price.Amount < active.Amount {
+			active = price
+		}
+	}
+	return active
+}
+
+func (price *ItemPrice) appliesAt(at time.Time) bool {
+	if len(price.Conditions.ContextRestrictions) > 0 {
+		return false
+	}
+	if price.Conditions.StartTime != nil && at.Before(*price.Conditions.StartTime) {
+		return false
+	}
+	if price.Conditions.EndTime != nil && !at.Before(*price.Conditions.EndTime) {
+		return false
+	}
+	return true
+}
+
+func (ml *MeLi) GetItemPrices(itemId ProductId) (*ItemPrices, error) {
+	if itemId == "" {
+		return nil, ErrNilProductId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/items/%v/prices", params, itemId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	prices := &ItemPrices{}
+	err = json.NewDecoder(resp.Body).Decode(prices)
+	if err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
@@ -0,0 +1,134 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_AddItemToPromotion(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	finish := start.AddDate(0, 0, 7)
+	params := url.Values{"access_token": []string{"foo"}, "app_version": []string{"v2"}}
+	tests := []struct {
+		name    string
+		itemId  ProductId
+		offer   *PromotionOffer
+		stub    *httpstub.Stub
+		wantErr error
+	}{
+		{
+			name:    "NIL ITEM ID",
+			offer:   &PromotionOffer{PromotionId: "P-1", PromotionType: PromotionDeal, DealPrice: 10},
+			wantErr: ErrNilProductId,
+		},
+		{
+			name:    "NIL DEAL PRICE",
+			itemId:  "MLA1",
+			offer:   &PromotionOffer{PromotionId: "P-1", PromotionType: PromotionDeal},
+			wantErr: ErrNilDealPrice,
+		},
+		{
+			name:    "CAMPAIGN WITHOUT ID",
+			itemId:  "MLA1",
+			offer:   &PromotionOffer{PromotionType: PromotionDeal, DealPrice: 10},
+			wantErr: ErrNilPromotionId,
+		},
+		{
+			name:    "DISCOUNT FINISHES BEFORE it STARTS",
+			itemId:  "MLA1",
+			offer:   &PromotionOffer{PromotionType: PromotionPriceDiscount, DealPrice: 10, StartDate: &finish, FinishDate: &start},
+			wantErr: ErrInvalidPromotionDates,
+		},
+		{
+			name:   "REMOTE returns an ERR",
+			itemId: "MLA1",
+			offer:  &PromotionOffer{PromotionId: "P-1", PromotionType: PromotionDeal, DealPrice: 10},
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/seller-promotions/items/MLA1",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{Params: params,
+					Body: []byte(`{"promotion_id":"P-1","promotion_type":"DEAL","deal_price":10}`),
+				},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "DISCOUNT is ADDED CORRECTly",
+			itemId: "MLA1",
+			offer:  &PromotionOffer{PromotionType: PromotionPriceDiscount, DealPrice: 9.99, StartDate: &start, FinishDate: &finish},
+			stub: &httpstub.Stub{Status: 201,
+				URL: "/seller-promotions/items/MLA1",
+				Receive: httpstub.Receive{Params: params,
+					Body: []byte(`{"promotion_type":"PRICE_DISCOUNT","deal_price":9.99,` +
+						`"start_date":"2020-01-01T00:00:00Z","finish_date":"2020-01-08T00:00:00Z"}`),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			err := ml.AddItemToPromotion(tt.itemId, tt.offer)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.AddItemToPromotion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestItemPrices_Active(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC)
+	start, finish := now.AddDate(0, 0, -1), now.AddDate(0, 0, 1)
+	standard := &ItemPrice{Id: "1", Type: "standard", Amount: 100}
+	promo := &ItemPrice{Id: "2", Type: "promotion", Amount: 80, RegularAmount: 100}
+	promo.Conditions.StartTime, promo.Conditions.EndTime = &start, &finish
+	loyalty := &ItemPrice{Id: "3", Type: "promotion", Amount: 70, RegularAmount: 100}
+	loyalty.Conditions.ContextRestrictions = []string{"user_level_6"}
+
+	tests := []struct {
+		name   string
+		prices *ItemPrices
+		at     time.Time
+		want   *ItemPrice
+	}{
+		{
+			name:   "PROMOTION is ACTIVE",
+			prices: &ItemPrices{Prices: []*ItemPrice{standard, promo, loyalty}},
+			at:     now,
+			want:   promo,
+		},
+		{
+			name:   "PROMOTION has FINISHED",
+			prices: &ItemPrices{Prices: []*ItemPrice{standard, promo, loyalty}},
+			at:     finish,
+			want:   standard,
+		},
+		{
+			name:   "NO PRICES",
+			prices: &ItemPrices{},
+			at:     now,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, tt.prices.Active(tt.at)); diff != "" {
+				t.Errorf("ItemPrices.Active() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
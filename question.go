@@ -0,0 +1,331 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+type QuestionId int64
+
+type Question struct {
+	Id          QuestionId     `json:"id,omitempty"`
+	ItemId      ProductId      `json:"item_id,omitempty"`
+	SellerId    int            `json:"seller_id,omitempty"`
+	Status      QuestionStatus `json:"status,omitempty"`
+	Text        string         `json:"text,omitempty"`
+	DateCreated time.Time      `json:"date_created,omitempty"`
+	From        *Asker         `json:"from,omitempty"`
+	Answer      *Answer        `json:"answer,omitempty"`
+	Deleted     bool           `json:"deleted_from_listing,omitempty"`
+	Hold        bool           `json:"hold,omitempty"`
+}
+
+type QuestionStatus string
+
+const (
+	QuestionUnanswered       QuestionStatus = "UNANSWERED"
+	QuestionAnswered         QuestionStatus = "ANSWERED"
+	QuestionClosedUnanswered QuestionStatus = "CLOSED_UNANSWERED"
+	QuestionUnderReview      QuestionStatus = "UNDER_REVIEW"
+	QuestionBanned           QuestionStatus = "BANNED"
+	QuestionDeleted          QuestionStatus = "DELETED"
+	QuestionDisabled         QuestionStatus = "DISABLED"
+)
+
+// Asker is the buyer who made the question
+type Asker struct {
+	Id                int `json:"id,omitempty"`
+	AnsweredQuestions int `json:"answered_questions,omitempty"`
+}
+
+type Answer struct {
+	Text        string    `json:"text,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	DateCreated time.Time `json:"date_created,omitempty"`
+}
+
+func (ml *MeLi) GetQuestion(id QuestionId) (*Question, error) {
+	if id == 0 {
+		return nil, ErrNilQuestionId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/questions/%v", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	q := &Question{}
+	err = json.NewDecoder(resp.Body).Decode(q)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// QuestionSearch filters the questions of an item or, lacking it, of every item of a seller
+type QuestionSearch struct {
+	ItemId   ProductId
+	SellerId int
+	Status   QuestionStatus
+	Offset   int
+	Limit    int
+}
+
+type QuestionEdge struct {
+	Total     int         `json:"total"`
+	Limit     int         `json:"limit"`
+	Questions []*Question `json:"questions"`
+}
+
+// SearchQuestions retrieves a page of the questions matching the given search
+func (ml *MeLi) SearchQuestions(search *QuestionSearch) (*QuestionEdge, error) {
+	if search == nil || (search.ItemId == "" && search.SellerId == 0) {
+		return nil, ErrNilQuestionSearch
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	if search.ItemId != "" {
+		params.Set("item", string(search.ItemId))
+	} else {
+		params.Set("seller_id", strconv.Itoa(search.SellerId))
+	}
+	if search.Status != "" {
+		params.Set("status", string(search.Status))
+	}
+	if search.Offset > 0 {
+		params.Set("offset", strconv.Itoa(search.Offset))
+	}
+	if search.Limit > 0 {
+		params.Set("limit", strconv.Itoa(search.Limit))
+	}
+	URL, err := ml.RouteTo("/questions/search", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &QuestionEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+// SearchAllQuestions walks every page of the given search, starting from its offset
+func (ml *MeLi) SearchAllQuestions(search *QuestionSearch) ([]*Question, error) {
+	if search == nil {
+		return nil, ErrNilQuestionSearch
+	}
+	page := *search
+	var qs []*Question
+	for {
+		edge, err := ml.SearchQuestions(&page)
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, edge.Questions...)
+		page.Offset += len(edge.Questions)
+		if len(edge.Questions) == 0 || page.Offset >= edge.Total {
+			break
+		}
+	}
+	return qs, nil
+}
+
+type answerRequest struct {
+	QuestionId QuestionId `json:"question_id"`
+	Text       string     `json:"text"`
+}
+
+// AnswerQuestion answers the question, retrieving it with its answer
+func (ml *MeLi) AnswerQuestion(id QuestionId, text string) (*Question, error) {
+	if id == 0 {
+		return nil, ErrNilQuestionId
+	}
+	if text == "" {
+		return nil, ErrNilAnswer
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/answers", params)
+	if err != nil {
+		return nil, err
+	}
+	reqBody, err := json.Marshal(&answerRequest{QuestionId: id, Text: text})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Post(URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	q := &Question{}
+	err = json.NewDecoder(resp.Body).Decode(q)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func (ml *MeLi) DeleteQuestion(id QuestionId) error {
+	if id == 0 {
+		return ErrNilQuestionId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return err
+	}
+	URL, err := ml.RouteTo("/questions/%v", params, id)
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Delete(URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
+type questionsBlacklist struct {
+	Users []struct {
+		Id int `json:"id"`
+	} `json:"users"`
+}
+
+type blockedUser struct {
+	UserId int `json:"user_id"`
+}
+
+// QuestionsBlacklist retrieves the ids of the users who can't ask questions to the seller
+func (ml *MeLi) QuestionsBlacklist(sellerId int) ([]int, error) {
+	if sellerId == 0 {
+		return nil, ErrNilSellerId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/users/%v/questions_blacklist", params, sellerId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	blacklist := &questionsBlacklist{}
+	err = json.NewDecoder(resp.Body).Decode(blacklist)
+	if err != nil {
+		return nil, err
+	}
+	userIds := make([]int, 0, len(blacklist.Users))
+	for _, user := range blacklist.Users {
+		userIds = append(userIds, user.Id)
+	}
+	return userIds, nil
+}
+
+// BlockQuestionsFrom adds the user to the questions blacklist of the seller
+func (ml *MeLi) BlockQuestionsFrom(sellerId, userId int) error {
+	if sellerId == 0 {
+		return ErrNilSellerId
+	}
+	if userId == 0 {
+		return ErrNilUserId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return err
+	}
+	URL, err := ml.RouteTo("/users/%v/questions_blacklist", params, sellerId)
+	if err != nil {
+		return err
+	}
+	reqBody, err := json.Marshal(&blockedUser{UserId: userId})
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Post(URL, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
+// UnblockQuestionsFrom removes the user from the questions blacklist of the seller
+func (ml *MeLi) UnblockQuestionsFrom(sellerId, userId int) error {
+	if sellerId == 0 {
+		return ErrNilSellerId
+	}
+	if userId == 0 {
+		return ErrNilUserId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return err
+	}
+	URL, err := ml.RouteTo("/users/%v/questions_blacklist/%v", params, sellerId, userId)
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Delete(URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	return nil
+}
+
+func (ml *MeLi) ProcessQuestionWebhook(wh *Webhook) (*Question, error) {
+	id, err := wh.resourceIdOf(ResourceQuestions)
+	if err != nil {
+		return nil, err
+	}
+	qId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidResource
+	}
+	return ml.GetQuestion(QuestionId(qId))
+}
@@ -0,0 +1,156 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_SearchQuestions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		creds    *creds
+		search   *QuestionSearch
+		stub     *httpstub.Stub
+		wantEdge *QuestionEdge
+		wantErr  error
+	}{
+		{
+			name:    "NIL ITEM NOR SELLER",
+			creds:   &creds{Access: "foo"},
+			search:  &QuestionSearch{Status: QuestionUnanswered},
+			wantErr: ErrNilQuestionSearch,
+		},
+		{
+			name:    "NIL CREDENTIALS",
+			creds:   &creds{},
+			search:  &QuestionSearch{ItemId: "MLA1"},
+			wantErr: ErrNilAccessToken,
+		},
+		{
+			name:   "REMOTE returns an ERR",
+			creds:  &creds{Access: "foo"},
+			search: &QuestionSearch{SellerId: 1},
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/questions/search",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"}, "seller_id": []string{"1"},
+				}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			creds:  &creds{Access: "foo"},
+			search: &QuestionSearch{ItemId: "MLA1", Status: QuestionUnanswered, Offset: 50, Limit: 50},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/questions/search",
+				Body: &QuestionEdge{Total: 51, Limit: 50, Questions: []*Question{
+					{Id: 2, ItemId: "MLA1", Status: QuestionUnanswered, Text: "bar", From: &Asker{Id: 3}},
+				}},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"},
+					"item":         []string{"MLA1"},
+					"status":       []string{"UNANSWERED"},
+					"offset":       []string{"50"},
+					"limit":        []string{"50"},
+				}},
+			},
+			wantEdge: &QuestionEdge{Total: 51, Limit: 50, Questions: []*Question{
+				{Id: 2, ItemId: "MLA1", Status: QuestionUnanswered, Text: "bar", From: &Asker{Id: 3}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: tt.creds}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotEdge, err := ml.SearchQuestions(tt.search)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.SearchQuestions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantEdge, gotEdge); diff != "" {
+				t.Errorf("MeLi.SearchQuestions() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_AnswerQuestion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		id      QuestionId
+		text    string
+		stub    *httpstub.Stub
+		wantQ   *Question
+		wantErr error
+	}{
+		{
+			name:    "NIL QUESTION ID",
+			text:    "bar",
+			wantErr: ErrNilQuestionId,
+		},
+		{
+			name:    "NIL ANSWER",
+			id:      1,
+			wantErr: ErrNilAnswer,
+		},
+		{
+			name: "REMOTE returns an ERR",
+			id:   1,
+			text: "bar",
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/answers",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{
+					Params: url.Values{"access_token": []string{"foo"}},
+					Body:   []byte(`{"question_id":1,"text":"bar"}`),
+				},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name: "REMOTE returns CORRECTly",
+			id:   1,
+			text: "bar",
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/answers",
+				Body: &Question{Id: 1, Status: QuestionAnswered, Answer: &Answer{Text: "bar", Status: "ACTIVE"}},
+				Receive: httpstub.Receive{
+					Params: url.Values{"access_token": []string{"foo"}},
+					Body:   []byte(`{"question_id":1,"text":"bar"}`),
+				},
+			},
+			wantQ: &Question{Id: 1, Status: QuestionAnswered, Answer: &Answer{Text: "bar", Status: "ACTIVE"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotQ, err := ml.AnswerQuestion(tt.id, tt.text)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.AnswerQuestion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantQ, gotQ); diff != "" {
+				t.Errorf("MeLi.AnswerQuestion() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package meli
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// CostSource retrieves the cost of the product (or of its variant, if any), reporting if it's known
+type CostSource func(prod *Product, v *Variant) (cost float64, ok bool)
+
+// PriceRule prices the products of a category (or of every one, if it's empty) as
+// cost × margin, clamped to min/max (0 means unbounded) and then rounded up to the given cents ending (e.g. .99).
+// If the ending exceeds the max, it's rounded down to the previous ending instead
+type PriceRule struct {
+	CategoryId CategoryId
+	Margin     float64
+	Ending     float64
+	Min        float64
+	Max        float64
+}
+
+func (rule *PriceRule) validate() error {
+	if rule == nil || rule.Margin <= 0 {
+		return ErrInvalidPriceRule
+	}
+	if rule.Ending < 0 || rule.Ending >= 1 {
+		return ErrInvalidPriceRule
+	}
+	if rule.Min < 0 || rule.Max < 0 || (rule.Max > 0 && rule.Min > rule.Max) {
+		return ErrInvalidPriceRule
+	}
+	return nil
+}
+
+func (rule *PriceRule) matches(prod *Product) bool {
+	return rule.CategoryId == "" || rule.CategoryId == prod.CategoryId
+}
+
+// Price evaluates the rule over the given cost
+func (rule *PriceRule) Price(cost float64) float64 {
+	price := cost * rule.Margin
+	if rule.Min > 0 && price < rule.Min {
+		price = rule.Min
+	}
+	if rule.Max > 0 && price > rule.Max {
+		price = rule.Max
+	}
+	if rule.Ending > 0 {
+		ended := math.Floor(price) + rule.Ending
+		if ended < price {
+			ended++
+		}
+		if rule.Max > 0 && ended > rule.Max {
+			ended-- // The previous ending, which is below the price
+		}
+		if ended >= rule.Min && ended > 0 {
+			price = ended
+		}
+	}
+	return math.Round(price*100) / 100
+}
+
+// Repricer evaluates price rules over products, the first matching rule being the applied one
+type Repricer struct {
+	ml    *MeLi
+	cost  CostSource
+	rules []*PriceRule
+}
+
+func (ml *MeLi) NewRepricer(cost CostSource, rules ...*PriceRule) (*Repricer, error) {
+	if cost == nil {
+		return nil, ErrNilCostSource
+	}
+	for _, rule := range rules {
+		err := rule.validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Repricer{ml: ml, cost: cost, rules: rules}, nil
+}
+
+// PriceChange is the repricing of a product or, if VariantId isn't nil, of one of its variants
+type PriceChange struct {
+	ProductId ProductId
+	VariantId VariantId
+	OldPrice  float64
+	NewPrice  float64
+	Rule      *PriceRule
+}
+
+func (change *PriceChange) String() string {
+	if change.VariantId != 0 {
+		return fmt.Sprintf("%v/%v: %v -> %v", change.ProductId, change.VariantId, change.OldPrice, change.NewPrice)
+	}
+	return fmt.Sprintf("%v: %v -> %v", change.ProductId, change.OldPrice, change.NewPrice)
+}
+
+// Preview retrieves the changes the rules would perform over the given products, without applying them.
+// Products without a matching rule or a known cost are skipped, as the ones whose price wouldn't change
+func (r *Repricer) Preview(prods []*Product) []*PriceChange {
+	var changes []*PriceChange
+	for _, prod := range prods {
+		if prod == nil {
+			continue
+		}
+		rule := r.ruleFor(prod)
+		if rule == nil {
+			continue
+		}
+		if len(prod.Variants) == 0 {
+			if change := r.change(rule, prod, nil); change != nil {
+				changes = append(changes, change)
+			}
+			continue
+		}
+		for _, v := range prod.Variants {
+			if change := r.change(rule, prod, v); change != nil {
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes
+}
+
+func (r *Repricer) ruleFor(prod *Product) *PriceRule {
+	for _, rule := range r.rules {
+		if rule.matches(prod) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (r *Repricer) change(rule *PriceRule, prod *Product, v *Variant) *PriceChange {
+	cost, ok := r.cost(prod, v)
+	if !ok {
+		return nil
+	}
+	change := &PriceChange{ProductId: prod.Id, OldPrice: prod.Price, NewPrice: rule.Price(cost), Rule: rule}
+	if v != nil {
+		change.VariantId, change.OldPrice = v.Id, v.Price
+	}
+	if change.NewPrice == change.OldPrice {
+		return nil
+	}
+	return change
+}
+
+type RepricingOptions struct {
+	// Parallelism is the max quantity of products updated at once (defaults to 4)
+	Parallelism int
+}
+
+// RepricingReport summarizes the applied changes. A failed change doesn't fail the rest
+type RepricingReport struct {
+	Applied []*PriceChange
+	Failed  []*FailedPriceChange
+}
+
+type FailedPriceChange struct {
+	Change *PriceChange
+	Err    error
+}
+
+func (report *RepricingReport) Err() error {
+	if len(report.Failed) == 0 {
+		return nil
+	}
+	return report
+}
+
+func (report *RepricingReport) Error() string {
+	var strErr string
+	for _, failed := range report.Failed {
+		strErr += fmt.Sprintf("%v: %v; ", failed.Change, failed.Err)
+	}
+	return strErr
+}
+
+// Apply pushes the given changes to the remote. The changes of different products are pushed in parallel,
+// meanwhile the ones of the variants of a product are pushed in order
+func (r *Repricer) Apply(changes []*PriceChange, opts *RepricingOptions) *RepricingReport {
+	if opts == nil {
+		opts = &RepricingOptions{}
+	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = 4
+	}
+	var prodIds []ProductId
+	changesByProd := make(map[ProductId][]*PriceChange)
+	for _, change := range changes {
+		if _, ok := changesByProd[change.ProductId]; !ok {
+			prodIds = append(prodIds, change.ProductId)
+		}
+		changesByProd[change.ProductId] = append(changesByProd[change.ProductId], change)
+	}
+
+	report := &RepricingReport{}
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	sem := make(chan struct{}, parallelism)
+	for _, prodId := range prodIds {
+		prodChanges := changesByProd[prodId]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			for _, change := range prodChanges {
+				err := r.apply(change)
+				lock.Lock()
+				if err != nil {
+					report.Failed = append(report.Failed, &FailedPriceChange{Change: change, Err: err})
+				} else {
+					report.Applied = append(report.Applied, change)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (r *Repricer) apply(change *PriceChange) error {
+	if change.VariantId != 0 {
+		_, err := r.ml.updateVariant(&Variant{Id: change.VariantId, Price: change.NewPrice}, change.ProductId)
+		return err
+	}
+	return r.ml.updateProductFields(change.ProductId, map[string]interface{}{"price": change.NewPrice})
+}
@@ -0,0 +1,133 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestPriceRule_Price(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		rule *PriceRule
+		cost float64
+		want float64
+	}{
+		{name: "MARGIN", rule: &PriceRule{Margin: 1.5}, cost: 10, want: 15},
+		{name: "ENDING rounds UP", rule: &PriceRule{Margin: 1.3, Ending: 0.99}, cost: 10, want: 13.99},
+		{name: "ENDING over the CENTS", rule: &PriceRule{Margin: 1, Ending: 0.5}, cost: 12.75, want: 13.5},
+		{name: "CLAMPED to MIN", rule: &PriceRule{Margin: 1.2, Min: 50}, cost: 10, want: 50},
+		{name: "CLAMPED to MAX", rule: &PriceRule{Margin: 2, Max: 15}, cost: 10, want: 15},
+		{name: "ENDING over the MIN", rule: &PriceRule{Margin: 1.2, Ending: 0.99, Min: 50}, cost: 10, want: 50.99},
+		{name: "ENDING rounds DOWN under the MAX", rule: &PriceRule{Margin: 2, Ending: 0.99, Max: 15.5}, cost: 10, want: 14.99},
+		{name: "ENDING can't fit between MIN and MAX", rule: &PriceRule{Margin: 1, Ending: 0.99, Min: 15.2, Max: 15.5}, cost: 10, want: 15.2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.rule.Price(tt.cost); got != tt.want {
+				t.Errorf("PriceRule.Price() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepricer_Apply(t *testing.T) {
+	t.Parallel()
+	params := url.Values{"access_token": []string{"foo"}}
+	costs := map[string]float64{"MLA1": 10, "MLA2/7": 20, "MLA2/8": 20, "MLA3": 5}
+	cost := func(prod *Product, v *Variant) (float64, bool) {
+		key := string(prod.Id)
+		if v != nil {
+			key += fmt.Sprintf("/%v", v.Id)
+		}
+		c, ok := costs[key]
+		return c, ok
+	}
+	phoneRule := &PriceRule{CategoryId: "MLA1055", Margin: 1.5, Ending: 0.99}
+	defaultRule := &PriceRule{Margin: 2}
+	prods := []*Product{
+		{Id: "MLA1", CategoryId: "MLA1055", Price: 10},
+		{Id: "MLA2", CategoryId: "MLA1000", Variants: []*Variant{{Id: 7, Price: 30}, {Id: 8, Price: 40}}},
+		{Id: "MLA3", CategoryId: "MLA1000", Price: 8},
+		{Id: "MLA4", CategoryId: "MLA1000", Price: 8}, // Without cost
+	}
+	// The price of the variant 8 doesn't change
+	wantPreview := []*PriceChange{
+		{ProductId: "MLA1", OldPrice: 10, NewPrice: 15.99, Rule: phoneRule},
+		{ProductId: "MLA2", VariantId: 7, OldPrice: 30, NewPrice: 40, Rule: defaultRule},
+		{ProductId: "MLA3", OldPrice: 8, NewPrice: 10, Rule: defaultRule},
+	}
+
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	stubber := httpstub.Stubber{Client: ml, Stubs: []*httpstub.Stub{
+		{Status: 200, URL: "/items/MLA1", Body: &Product{Id: "MLA1"},
+			Receive: httpstub.Receive{Params: params, Body: []byte(`{"price":15.99}`)},
+		},
+		{Status: 200, URL: "/items/MLA2/variations/7", Body: &Variant{Id: 7},
+			Receive: httpstub.Receive{Params: params, Body: JSONMarshal(t, &Variant{Price: 40})},
+		},
+		{Status: 400, URL: "/items/MLA3", Body: svErrFooBar,
+			Receive: httpstub.Receive{Params: params, Body: []byte(`{"price":10}`)},
+		},
+	}}
+	cleanup := stubber.Serve(t)
+	defer cleanup()
+
+	r, err := ml.NewRepricer(cost, phoneRule, defaultRule)
+	if err != nil {
+		t.Fatalf("MeLi.NewRepricer() error = %v", err)
+	}
+	gotPreview := r.Preview(prods)
+	if diff := cmp.Diff(wantPreview, gotPreview); diff != "" {
+		t.Fatalf("Repricer.Preview() mismatch (-want +got): %s", diff)
+	}
+
+	report := r.Apply(gotPreview, &RepricingOptions{Parallelism: 2})
+	sort.Slice(report.Applied, func(i, j int) bool { return report.Applied[i].ProductId < report.Applied[j].ProductId })
+	if diff := cmp.Diff(wantPreview[:2], report.Applied); diff != "" {
+		t.Errorf("Repricer.Apply() applied mismatch (-want +got): %s", diff)
+	}
+	wantFailed := []*FailedPriceChange{{Change: wantPreview[2], Err: svErrFooBar}}
+	if diff := cmp.Diff(wantFailed, report.Failed, cmp.Comparer(func(x, y error) bool {
+		return fmt.Sprintf("%v", x) == fmt.Sprintf("%v", y)
+	})); diff != "" {
+		t.Errorf("Repricer.Apply() failed mismatch (-want +got): %s", diff)
+	}
+	if report.Err() == nil {
+		t.Errorf("RepricingReport.Err() is NIL, despite the FAILED changes")
+	}
+}
+
+func TestMeLi_NewRepricer(t *testing.T) {
+	t.Parallel()
+	cost := func(*Product, *Variant) (float64, bool) { return 0, false }
+	tests := []struct {
+		name    string
+		cost    CostSource
+		rules   []*PriceRule
+		wantErr error
+	}{
+		{name: "NIL COST SOURCE", rules: []*PriceRule{{Margin: 1}}, wantErr: ErrNilCostSource},
+		{name: "NIL MARGIN", cost: cost, rules: []*PriceRule{{}}, wantErr: ErrInvalidPriceRule},
+		{name: "INVALID ENDING", cost: cost, rules: []*PriceRule{{Margin: 1, Ending: 1}}, wantErr: ErrInvalidPriceRule},
+		{name: "MIN exceeds MAX", cost: cost, rules: []*PriceRule{{Margin: 1, Min: 10, Max: 5}}, wantErr: ErrInvalidPriceRule},
+		{name: "VALID rules", cost: cost, rules: []*PriceRule{{Margin: 1, Min: 5, Max: 10}, {Margin: 2}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := (&MeLi{}).NewRepricer(tt.cost, tt.rules...)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("MeLi.NewRepricer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package meli
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// searchMaxOffset is the max offset+limit the public search allows to reach
+	searchMaxOffset = 1000
+	// searchMaxLimit is the max quantity of results retrieved on each page
+	searchMaxLimit = 50
+)
+
+// searchReservedParams are the params set by the fields of a SearchQuery, which its filters can't override
+var searchReservedParams = []string{"q", "category", "seller_id", "sort", "offset", "limit", "access_token"}
+
+// SearchQuery filters the public listings of a site. It needs a site and, at least, a query, category or seller
+type SearchQuery struct {
+	SiteId     SiteId
+	Q          string
+	CategoryId CategoryId
+	SellerId   int
+	// Filters are the ids of the filters to apply (e.g. "condition") with the id of its value (e.g. "new").
+	// They can't override the rest of the params (see searchReservedParams)
+	Filters map[string]string
+	Sort    string // e.g. relevance, price_asc, price_desc
+	Offset  int
+	Limit   int
+}
+
+type SearchEdge struct {
+	SiteId           SiteId          `json:"site_id"`
+	Query            string          `json:"query,omitempty"`
+	Paging           Paging          `json:"paging"`
+	Results          []*SearchResult `json:"results"`
+	Sort             *SearchSort     `json:"sort,omitempty"`
+	AvailableSorts   []*SearchSort   `json:"available_sorts,omitempty"`
+	Filters          []*SearchFilter `json:"filters,omitempty"`
+	AvailableFilters []*SearchFilter `json:"available_filters,omitempty"`
+}
+
+// SearchResult is the public summary of a listing
+type SearchResult struct {
+	Id                ProductId        `json:"id"`
+	SiteId            SiteId           `json:"site_id,omitempty"`
+	Title             string           `json:"title"`
+	Price             float64          `json:"price"`
+	OriginalPrice     float64          `json:"original_price,omitempty"`
+	CurrencyId        CurrencyId       `json:"currency_id,omitempty"`
+	AvailableQuantity int              `json:"available_quantity,omitempty"`
+	SoldQuantity      int              `json:"sold_quantity,omitempty"`
+	BuyingMode        BuyingMode       `json:"buying_mode,omitempty"`
+	ListingTypeId     ListingTypeId    `json:"listing_type_id,omitempty"`
+	Condition         Condition        `json:"condition,omitempty"`
+	CategoryId        CategoryId       `json:"category_id,omitempty"`
+	CatalogProductId  CatalogProductId `json:"catalog_product_id,omitempty"`
+	Permalink         string           `json:"permalink,omitempty"`
+	Thumbnail         string           `json:"thumbnail,omitempty"`
+	Seller            *struct {
+		Id int `json:"id"`
+	} `json:"seller,omitempty"`
+	Shipping *struct {
+		FreeShipping bool   `json:"free_shipping"`
+		LogisticType string `json:"logistic_type,omitempty"`
+	} `json:"shipping,omitempty"`
+}
+
+type SearchSort struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type SearchFilter struct {
+	Id     string               `json:"id"`
+	Name   string               `json:"name"`
+	Type   string               `json:"type,omitempty"`
+	Values []*SearchFilterValue `json:"values"`
+}
+
+type SearchFilterValue struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Results int    `json:"results,omitempty"`
+}
+
+func (query *SearchQuery) params() (url.Values, error) {
+	if query == nil || query.SiteId == "" {
+		return nil, ErrNilSiteId
+	}
+	if query.Q == "" && query.CategoryId == "" && query.SellerId == 0 {
+		return nil, ErrNilSearchQuery
+	}
+	if query.Offset+query.Limit > searchMaxOffset {
+		return nil, ErrSearchOffsetExceeded
+	}
+	for id := range query.Filters {
+		for _, reserved := range searchReservedParams {
+			if id == reserved {
+				return nil, ErrReservedSearchFilter
+			}
+		}
+	}
+	params := url.Values{}
+	if query.Q != "" {
+		params.Set("q", query.Q)
+	}
+	if query.CategoryId != "" {
+		params.Set("category", string(query.CategoryId))
+	}
+	if query.SellerId != 0 {
+		params.Set("seller_id", strconv.Itoa(query.SellerId))
+	}
+	for id, value := range query.Filters {
+		params.Set(id, value)
+	}
+	if query.Sort != "" {
+		params.Set("sort", query.Sort)
+	}
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	return params, nil
+}
+
+// Search retrieves a page of the public listings matching the query. It doesn't need credentials
+func (ml *MeLi) Search(query *SearchQuery) (*SearchEdge, error) {
+	params, err := query.params()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/sites/%v/search", params, query.SiteId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	edge := &SearchEdge{}
+	err = json.NewDecoder(resp.Body).Decode(edge)
+	if err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+// SearchIterator walks the pages of a search, starting from its offset and up to the max offset the
+// remote allows to reach (so only the first 1000 results of a search can be walked):
+//
+//	it := ml.SearchResults(&SearchQuery{SiteId: "MLA", Q: "foo"})
+//	for it.Next() {
+//		res := it.Result()
+//	}
+//	err := it.Err()
+type SearchIterator struct {
+	ml      *MeLi
+	query   SearchQuery
+	total   int
+	started bool
+
+	page []*SearchResult
+	cur  *SearchResult
+	err  error
+}
+
+func (ml *MeLi) SearchResults(query *SearchQuery) *SearchIterator {
+	it := &SearchIterator{ml: ml}
+	if query == nil {
+		it.err = ErrNilSiteId
+		return it
+	}
+	it.query = *query
+	return it
+}
+
+// Next advances to the next result, fetching the next page when needed.
+// It returns false once there're no more results (or they can't be reached) or an err occurred
+func (it *SearchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.page) == 0 {
+		if it.started && it.query.Offset >= it.total {
+			return false
+		}
+		limit := searchMaxLimit
+		if it.query.Limit > 0 && it.query.Limit < limit {
+			limit = it.query.Limit
+		}
+		if it.query.Offset+limit > searchMaxOffset {
+			limit = searchMaxOffset - it.query.Offset
+		}
+		if limit <= 0 {
+			return false
+		}
+		page := it.query
+		page.Limit = limit
+		edge, err := it.ml.Search(&page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+		it.total = edge.Paging.Total
+		it.query.Offset += len(edge.Results)
+		it.page = edge.Results
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+func (it *SearchIterator) Result() *SearchResult {
+	return it.cur
+}
+
+func (it *SearchIterator) Err() error {
+	return it.err
+}
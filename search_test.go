@@ -0,0 +1,139 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_Search(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		query    *SearchQuery
+		stub     *httpstub.Stub
+		wantEdge *SearchEdge
+		wantErr  error
+	}{
+		{
+			name:    "NIL SITE ID",
+			query:   &SearchQuery{Q: "foo"},
+			wantErr: ErrNilSiteId,
+		},
+		{
+			name:    "NIL QUERY, CATEGORY NOR SELLER",
+			query:   &SearchQuery{SiteId: "MLA"},
+			wantErr: ErrNilSearchQuery,
+		},
+		{
+			name:    "OFFSET EXCEEDS the MAX",
+			query:   &SearchQuery{SiteId: "MLA", Q: "foo", Offset: 990, Limit: 50},
+			wantErr: ErrSearchOffsetExceeded,
+		},
+		{
+			name:    "FILTER OVERRIDES the OFFSET",
+			query:   &SearchQuery{SiteId: "MLA", Q: "foo", Filters: map[string]string{"offset": "5000"}},
+			wantErr: ErrReservedSearchFilter,
+		},
+		{
+			name:    "FILTER OVERRIDES the TOKEN",
+			query:   &SearchQuery{SiteId: "MLA", Q: "foo", Filters: map[string]string{"access_token": "bar"}},
+			wantErr: ErrReservedSearchFilter,
+		},
+		{
+			name:  "REMOTE returns an ERR",
+			query: &SearchQuery{SiteId: "MLA", CategoryId: "MLA1055"},
+			stub: &httpstub.Stub{Status: 400,
+				URL:     "/sites/MLA/search",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"category": []string{"MLA1055"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name: "REMOTE returns CORRECTly WITHOUT CREDENTIALS",
+			query: &SearchQuery{SiteId: "MLA", Q: "foo", SellerId: 1,
+				Filters: map[string]string{"condition": "new"}, Sort: "price_asc", Limit: 1,
+			},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/sites/MLA/search",
+				Body: &SearchEdge{SiteId: "MLA", Query: "foo", Paging: Paging{Total: 2, Limit: 1},
+					Results: []*SearchResult{{Id: "MLA1", Title: "foo bar", Price: 10, Condition: "new"}},
+					Filters: []*SearchFilter{{Id: "condition", Name: "Condición", Values: []*SearchFilterValue{{Id: "new", Name: "Nuevo"}}}},
+				},
+				Receive: httpstub.Receive{Params: url.Values{
+					"q":         []string{"foo"},
+					"seller_id": []string{"1"},
+					"condition": []string{"new"},
+					"sort":      []string{"price_asc"},
+					"limit":     []string{"1"},
+				}},
+			},
+			wantEdge: &SearchEdge{SiteId: "MLA", Query: "foo", Paging: Paging{Total: 2, Limit: 1},
+				Results: []*SearchResult{{Id: "MLA1", Title: "foo bar", Price: 10, Condition: "new"}},
+				Filters: []*SearchFilter{{Id: "condition", Name: "Condición", Values: []*SearchFilterValue{{Id: "new", Name: "Nuevo"}}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotEdge, err := ml.Search(tt.query)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantEdge, gotEdge); diff != "" {
+				t.Errorf("MeLi.Search() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSearchIterator_Next(t *testing.T) {
+	t.Parallel()
+	var results []*SearchResult
+	var want []ProductId
+	for i := 0; i < 20; i++ {
+		results = append(results, &SearchResult{Id: ProductId(fmt.Sprintf("MLA%v", i))})
+		want = append(want, ProductId(fmt.Sprintf("MLA%v", i)))
+	}
+	ml := &MeLi{}
+	stubber := httpstub.Stubber{Client: ml, Stubs: []*httpstub.Stub{
+		{Status: 200,
+			URL:  "/sites/MLA/search",
+			Body: &SearchEdge{SiteId: "MLA", Paging: Paging{Total: 5000, Offset: 980, Limit: 20}, Results: results},
+			// The limit is capped, so the max offset is never exceeded
+			Receive: httpstub.Receive{Params: url.Values{
+				"q":      []string{"foo"},
+				"offset": []string{"980"},
+				"limit":  []string{"20"},
+			}},
+		},
+	}}
+	cleanup := stubber.Serve(t)
+	defer cleanup()
+
+	var got []ProductId
+	it := ml.SearchResults(&SearchQuery{SiteId: "MLA", Q: "foo", Offset: 980})
+	for it.Next() {
+		got = append(got, it.Result().Id)
+		if len(got) > len(want) {
+			t.Fatalf("SearchIterator.Next() walked BEYOND the MAX OFFSET")
+		}
+	}
+	if it.Err() != nil {
+		t.Errorf("SearchIterator.Err() = %v", it.Err())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SearchIterator.Next() mismatch (-want +got): %s", diff)
+	}
+}
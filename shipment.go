@@ -0,0 +1,261 @@
+package meli
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLabelShipments is the max quantity of shipments whose labels can be downloaded at once
+const maxLabelShipments = 50
+
+// Dimensions of the package of a listing, in centimeters and grams.
+// It's (un)marshalled as the remote does: "HxWxL,WEIGHT" (e.g. "10x15x20,500")
+type Dimensions struct {
+	Height float64
+	Width  float64
+	Length float64
+	Weight int
+}
+
+func ParseDimensions(s string) (*Dimensions, error) {
+	sizes := strings.SplitN(s, ",", 2)
+	if len(sizes) != 2 {
+		return nil, ErrInvalidDimensions
+	}
+	measures := strings.Split(sizes[0], "x")
+	if len(measures) != 3 {
+		return nil, ErrInvalidDimensions
+	}
+	var hwl [3]float64
+	for i, measure := range measures {
+		f, err := strconv.ParseFloat(strings.TrimSpace(measure), 64)
+		if err != nil || f <= 0 {
+			return nil, ErrInvalidDimensions
+		}
+		hwl[i] = f
+	}
+	weight, err := strconv.Atoi(strings.TrimSpace(sizes[1]))
+	if err != nil || weight <= 0 {
+		return nil, ErrInvalidDimensions
+	}
+	return &Dimensions{Height: hwl[0], Width: hwl[1], Length: hwl[2], Weight: weight}, nil
+}
+
+func (d *Dimensions) String() string {
+	return formatMeasure(d.Height) + "x" + formatMeasure(d.Width) + "x" + formatMeasure(d.Length) +
+		"," + strconv.Itoa(d.Weight)
+}
+
+func formatMeasure(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func (d *Dimensions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON leaves the dimensions untouched when they're empty or unparseable, rather than failing
+// the whole document (see Shipping.UnmarshalJSON)
+func (d *Dimensions) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) != nil {
+		return nil
+	}
+	parsed, err := ParseDimensions(s)
+	if err != nil {
+		return nil
+	}
+	*d = *parsed
+	return nil
+}
+
+type ShipmentId int64
+
+type Shipment struct {
+	Id             ShipmentId     `json:"id,omitempty"`
+	OrderId        OrderId        `json:"order_id,omitempty"`
+	Status         ShipmentStatus `json:"status,omitempty"`
+	Substatus      string         `json:"substatus,omitempty"`
+	Mode           string         `json:"mode,omitempty"`
+	LogisticType   string         `json:"logistic_type,omitempty"`
+	TrackingNumber string         `json:"tracking_number,omitempty"`
+	TrackingMethod string         `json:"tracking_method,omitempty"`
+	DateCreated    time.Time      `json:"date_created,omitempty"`
+	LastUpdated    time.Time      `json:"last_updated,omitempty"`
+
+	SenderId        int              `json:"sender_id,omitempty"`
+	ReceiverId      int              `json:"receiver_id,omitempty"`
+	SenderAddress   *ShipmentAddress `json:"sender_address,omitempty"`
+	ReceiverAddress *ShipmentAddress `json:"receiver_address,omitempty"`
+
+	StatusHistory *ShipmentDates `json:"status_history,omitempty"`
+}
+
+type ShipmentStatus string
+
+const (
+	ShipmentPending      ShipmentStatus = "pending"
+	ShipmentHandling     ShipmentStatus = "handling"
+	ShipmentReadyToShip  ShipmentStatus = "ready_to_ship"
+	ShipmentShipped      ShipmentStatus = "shipped"
+	ShipmentDelivered    ShipmentStatus = "delivered"
+	ShipmentNotDelivered ShipmentStatus = "not_delivered"
+	ShipmentCancelled    ShipmentStatus = "cancelled"
+)
+
+type ShipmentAddress struct {
+	Id           int64  `json:"id,omitempty"`
+	AddressLine  string `json:"address_line,omitempty"`
+	StreetName   string `json:"street_name,omitempty"`
+	StreetNumber string `json:"street_number,omitempty"`
+	ZipCode      string `json:"zip_code,omitempty"`
+	Comment      string `json:"comment,omitempty"`
+	City         struct {
+		Id   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"city,omitempty"`
+	State struct {
+		Id   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"state,omitempty"`
+	ReceiverName  string `json:"receiver_name,omitempty"`
+	ReceiverPhone string `json:"receiver_phone,omitempty"`
+}
+
+// ShipmentDates are the dates on which the shipment reached each of its main statuses
+type ShipmentDates struct {
+	DateHandling     *time.Time `json:"date_handling,omitempty"`
+	DateReadyToShip  *time.Time `json:"date_ready_to_ship,omitempty"`
+	DateShipped      *time.Time `json:"date_shipped,omitempty"`
+	DateDelivered    *time.Time `json:"date_delivered,omitempty"`
+	DateNotDelivered *time.Time `json:"date_not_delivered,omitempty"`
+	DateCancelled    *time.Time `json:"date_cancelled,omitempty"`
+	DateFirstVisit   *time.Time `json:"date_first_visit,omitempty"`
+	DateReturned     *time.Time `json:"date_returned,omitempty"`
+	DateFirstPrinted *time.Time `json:"date_first_printed,omitempty"`
+}
+
+// ShipmentStatusChange is an entry of the status history of a shipment
+type ShipmentStatusChange struct {
+	Status    ShipmentStatus `json:"status"`
+	Substatus string         `json:"substatus,omitempty"`
+	Date      time.Time      `json:"date"`
+}
+
+func (ml *MeLi) GetShipment(id ShipmentId) (*Shipment, error) {
+	if id == 0 {
+		return nil, ErrNilShipmentId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/shipments/%v", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	shipment := &Shipment{}
+	err = json.NewDecoder(resp.Body).Decode(shipment)
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// ShipmentHistory retrieves every status change of the shipment, from the oldest to the newest
+func (ml *MeLi) ShipmentHistory(id ShipmentId) ([]*ShipmentStatusChange, error) {
+	if id == 0 {
+		return nil, ErrNilShipmentId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/shipments/%v/history", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	var history []*ShipmentStatusChange
+	err = json.NewDecoder(resp.Body).Decode(&history)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+type LabelFormat string
+
+const (
+	LabelPDF LabelFormat = "pdf"
+	// LabelZPL retrieves a zip file containing the labels for thermal printers
+	LabelZPL LabelFormat = "zpl2"
+)
+
+// DownloadLabels writes onto w the shipping labels of the given shipments, which must be ready to ship.
+// Many shipments are merged onto a single file
+func (ml *MeLi) DownloadLabels(w io.Writer, format LabelFormat, ids ...ShipmentId) error {
+	if format != LabelPDF && format != LabelZPL {
+		return ErrInvalidLabelFormat
+	}
+	if len(ids) == 0 || len(ids) > maxLabelShipments {
+		return ErrInvalidMultigetQuantity
+	}
+	strIds := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			return ErrNilShipmentId
+		}
+		strIds = append(strIds, strconv.FormatInt(int64(id), 10))
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return err
+	}
+	params.Set("shipment_ids", strings.Join(strIds, ","))
+	params.Set("response_type", string(format))
+	URL, err := ml.RouteTo("/shipment_labels", params)
+	if err != nil {
+		return err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errFromReader(resp.Body)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (ml *MeLi) ProcessShipmentWebhook(wh *Webhook) (*Shipment, error) {
+	id, err := wh.resourceIdOf(ResourceShipments)
+	if err != nil {
+		return nil, err
+	}
+	shipmentId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidResource
+	}
+	return ml.GetShipment(ShipmentId(shipmentId))
+}
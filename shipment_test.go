@@ -0,0 +1,187 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestDimensions_JSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		json string
+		want *Shipping
+	}{
+		{
+			name: "INTEGER measures",
+			json: `{"dimensions":"10x15x20,500"}`,
+			want: &Shipping{Dimensions: &Dimensions{Height: 10, Width: 15, Length: 20, Weight: 500}},
+		},
+		{
+			name: "DECIMAL measures",
+			json: `{"dimensions":"10.5x15x20,500"}`,
+			want: &Shipping{Dimensions: &Dimensions{Height: 10.5, Width: 15, Length: 20, Weight: 500}},
+		},
+		{
+			name: "NULL dimensions",
+			json: `{"dimensions":null}`,
+			want: &Shipping{},
+		},
+		{
+			name: "EMPTY dimensions",
+			json: `{"dimensions":""}`,
+			want: &Shipping{},
+		},
+		{
+			name: "UNPARSEABLE dimensions are left NIL",
+			json: `{"dimensions":"10x15x20,0.5"}`,
+			want: &Shipping{},
+		},
+		{
+			name: "dimensions are NOT a STRING",
+			json: `{"dimensions":10}`,
+			want: &Shipping{},
+		},
+		{
+			name: "dimensions are left UNTOUCHED when ABSENT",
+			json: `{"mode":"me2"}`,
+			want: &Shipping{Mode: ShippingMe2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := &Shipping{}
+			err := json.Unmarshal([]byte(tt.json), got)
+			if err != nil {
+				t.Fatalf("Dimensions.UnmarshalJSON() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Dimensions.UnmarshalJSON() mismatch (-want +got): %s", diff)
+			}
+			marshalled, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Dimensions.MarshalJSON() error = %v", err)
+			}
+			if string(marshalled) != tt.json && got.Dimensions != nil {
+				t.Errorf("Dimensions.MarshalJSON() = %s, want %s", marshalled, tt.json)
+			}
+		})
+	}
+}
+
+func TestParseDimensions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    *Dimensions
+		wantErr error
+	}{
+		{name: "VALID dimensions", s: "10x15.5x20,500", want: &Dimensions{Height: 10, Width: 15.5, Length: 20, Weight: 500}},
+		{name: "WEIGHT is MISSING", s: "10x15x20", wantErr: ErrInvalidDimensions},
+		{name: "a MEASURE is MISSING", s: "10x15,500", wantErr: ErrInvalidDimensions},
+		{name: "DECIMAL weight", s: "10x15x20,0.5", wantErr: ErrInvalidDimensions},
+		{name: "ZERO weight", s: "10x15x20,0", wantErr: ErrInvalidDimensions},
+		{name: "EMPTY", wantErr: ErrInvalidDimensions},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseDimensions(tt.s)
+			if err != tt.wantErr {
+				t.Errorf("ParseDimensions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseDimensions() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_DownloadLabels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		format  LabelFormat
+		ids     []ShipmentId
+		stub    *httpstub.Stub
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "INVALID FORMAT",
+			format:  "png",
+			ids:     []ShipmentId{1},
+			wantErr: ErrInvalidLabelFormat,
+		},
+		{
+			name:    "NO SHIPMENTS",
+			format:  LabelPDF,
+			wantErr: ErrInvalidMultigetQuantity,
+		},
+		{
+			name:    "NIL SHIPMENT ID",
+			format:  LabelPDF,
+			ids:     []ShipmentId{1, 0},
+			wantErr: ErrNilShipmentId,
+		},
+		{
+			name:   "REMOTE returns an ERR",
+			format: LabelPDF,
+			ids:    []ShipmentId{1},
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/shipment_labels",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token":  []string{"foo"},
+					"shipment_ids":  []string{"1"},
+					"response_type": []string{"pdf"},
+				}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			format: LabelZPL,
+			ids:    []ShipmentId{1, 2},
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/shipment_labels",
+				Body: "bar",
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token":  []string{"foo"},
+					"shipment_ids":  []string{"1,2"},
+					"response_type": []string{"zpl2"},
+				}},
+			},
+			want: "\"bar\"\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			w := &bytes.Buffer{}
+			err := ml.DownloadLabels(w, tt.format, tt.ids...)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.DownloadLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if w.String() != tt.want {
+				t.Errorf("MeLi.DownloadLabels() wrote %q, want %q", w.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package meli
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// maxShippingCosts is the max quantity of costs a custom shipping can offer
+const maxShippingCosts = 10
+
+// Shipping is the shipping configuration of a listing
+type Shipping struct {
+	Mode         ShippingMode          `json:"mode,omitempty"`
+	Methods      []*ShippingMethod     `json:"methods,omitempty"`
+	FreeMethods  []*FreeShippingMethod `json:"free_methods,omitempty"`
+	Costs        []*ShippingCost       `json:"costs,omitempty"`
+	Tags         []string              `json:"tags,omitempty"`
+	Dimensions   *Dimensions           `json:"dimensions,omitempty"`
+	LocalPickUp  bool                  `json:"local_pick_up,omitempty"`
+	FreeShipping bool                  `json:"free_shipping,omitempty"`
+	LogisticType string                `json:"logistic_type,omitempty"`
+	StorePickUp  bool                  `json:"store_pick_up,omitempty"`
+}
+
+// UnmarshalJSON treats the empty dimensions ("" or null), which the remote retrieves for the listings
+// lacking them, as nil ones
+func (s *Shipping) UnmarshalJSON(data []byte) error {
+	type plainShipping Shipping // Lacks the methods of Shipping, so it doesn't recurse
+	aux := &struct {
+		*plainShipping
+		Dimensions json.RawMessage `json:"dimensions,omitempty"`
+	}{plainShipping: (*plainShipping)(s)}
+	err := json.Unmarshal(data, aux)
+	if err != nil {
+		return err
+	}
+	if len(aux.Dimensions) == 0 { // Absent, so they're left untouched
+		return nil
+	}
+	var raw string // Left empty when they're null (or not even a string)
+	_ = json.Unmarshal(aux.Dimensions, &raw)
+	// Unparseable dimensions are left nil rather than failing the whole document (ParseDimensions validates them)
+	s.Dimensions, _ = ParseDimensions(raw)
+	return nil
+}
+
+type ShippingMode string
+
+const (
+	// ShippingMe2 is the shipping managed by MercadoEnvíos, whose costs are calculated by the remote
+	ShippingMe2 ShippingMode = "me2"
+	ShippingMe1 ShippingMode = "me1"
+	// ShippingCustom lets the seller define its own costs
+	ShippingCustom       ShippingMode = "custom"
+	ShippingNotSpecified ShippingMode = "not_specified"
+)
+
+func (mode ShippingMode) validate() error {
+	for _, validMode := range []ShippingMode{ShippingMe2, ShippingMe1, ShippingCustom, ShippingNotSpecified} {
+		if mode == validMode {
+			return nil
+		}
+	}
+	return ErrInvalidShippingMode
+}
+
+type ShippingMethod struct {
+	Id int `json:"id"`
+}
+
+// FreeShippingMethod is a shipping method whose cost is paid by the seller
+type FreeShippingMethod struct {
+	Id   int `json:"id"`
+	Rule struct {
+		Default          bool   `json:"default,omitempty"`
+		FreeMode         string `json:"free_mode,omitempty"`
+		FreeShippingFlag bool   `json:"free_shipping_flag,omitempty"`
+	} `json:"rule"`
+}
+
+// ShippingCost is an option offered by a custom shipping
+type ShippingCost struct {
+	Description string  `json:"description"`
+	Cost        float64 `json:"cost,string"`
+}
+
+// NewMe2Shipping creates a shipping managed by MercadoEnvíos, optionally paid by the seller
+func NewMe2Shipping(free bool) *Shipping {
+	return &Shipping{Mode: ShippingMe2, FreeShipping: free}
+}
+
+// NewCustomShipping creates a shipping offering the given costs, which must be between 1 and 10
+func NewCustomShipping(localPickUp bool, costs ...*ShippingCost) (*Shipping, error) {
+	shipping := &Shipping{Mode: ShippingCustom, LocalPickUp: localPickUp, Costs: costs}
+	err := shipping.validate()
+	if err != nil {
+		return nil, err
+	}
+	return shipping, nil
+}
+
+// NewNotSpecifiedShipping creates a shipping to be agreed with the buyer
+func NewNotSpecifiedShipping(localPickUp bool) *Shipping {
+	return &Shipping{Mode: ShippingNotSpecified, LocalPickUp: localPickUp}
+}
+
+func (s *Shipping) validate() error {
+	err := s.Mode.validate()
+	if err != nil {
+		return err
+	}
+	switch s.Mode {
+	case ShippingCustom:
+		if len(s.Costs) == 0 {
+			return ErrNilShippingCosts
+		}
+		if len(s.Costs) > maxShippingCosts {
+			return ErrTooManyShippingCosts
+		}
+		if s.FreeShipping {
+			return ErrInvalidFreeShipping
+		}
+	case ShippingNotSpecified:
+		if s.FreeShipping {
+			return ErrInvalidFreeShipping
+		}
+	}
+	return nil
+}
+
+// Validate checks the shipping is consistent and its mode is allowed by the given category
+func (s *Shipping) Validate(cat *Category) error {
+	if cat == nil {
+		return ErrNilCategory
+	}
+	err := s.validate()
+	if err != nil {
+		return err
+	}
+	modes := cat.ShippingModes
+	if len(modes) == 0 && cat.Settings != nil {
+		modes = cat.Settings.ShippingModes
+	}
+	if len(modes) == 0 {
+		return nil // The category doesn't restrict them
+	}
+	for _, mode := range modes {
+		if mode == s.Mode {
+			return nil
+		}
+	}
+	return ErrInvalidShippingMode
+}
+
+// ValidateProductShipping checks the shipping of the product against its category
+func (ml *MeLi) ValidateProductShipping(prod *Product) error {
+	if prod == nil {
+		return ErrNilProduct
+	}
+	if prod.Shipping == nil {
+		return nil
+	}
+	cat, err := ml.GetCategory(prod.CategoryId)
+	if err != nil {
+		return err
+	}
+	return prod.Shipping.Validate(cat)
+}
+
+// FreeShippingQuery describes the listing whose free shipping cost is wanted to be estimated
+type FreeShippingQuery struct {
+	Dimensions    *Dimensions
+	ItemPrice     float64
+	ListingTypeId ListingTypeId
+	Mode          ShippingMode // Defaults to me2
+	Condition     Condition
+	LogisticType  string
+}
+
+// FreeShippingCost is the cost the seller pays to ship the listing for free to the whole country
+type FreeShippingCost struct {
+	ListCost       float64    `json:"list_cost"`
+	CurrencyId     CurrencyId `json:"currency_id"`
+	BillableWeight float64    `json:"billable_weight"`
+}
+
+type freeShippingOptions struct {
+	Coverage struct {
+		AllCountry *FreeShippingCost `json:"all_country"`
+	} `json:"coverage"`
+}
+
+// FreeShippingCost estimates the cost the seller would pay to offer free shipping on the described listing
+func (ml *MeLi) FreeShippingCost(sellerId int, query *FreeShippingQuery) (*FreeShippingCost, error) {
+	if sellerId == 0 {
+		return nil, ErrNilSellerId
+	}
+	if query == nil || query.Dimensions == nil {
+		return nil, ErrNilDimensions
+	}
+	if query.ItemPrice == 0 {
+		return nil, ErrNilPrice
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	mode := query.Mode
+	if mode == "" {
+		mode = ShippingMe2
+	}
+	params.Set("dimensions", query.Dimensions.String())
+	params.Set("item_price", strconv.FormatFloat(query.ItemPrice, 'f', -1, 64))
+	params.Set("mode", string(mode))
+	if query.ListingTypeId != "" {
+		params.Set("listing_type_id", string(query.ListingTypeId))
+	}
+	if query.Condition != "" {
+		params.Set("condition", string(query.Condition))
+	}
+	if query.LogisticType != "" {
+		params.Set("logistic_type", query.LogisticType)
+	}
+	URL, err := ml.RouteTo("/users/%v/shipping_options/free", params, sellerId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	opts := &freeShippingOptions{}
+	err = json.NewDecoder(resp.Body).Decode(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Coverage.AllCountry == nil {
+		return nil, ErrRemoteInconsistency
+	}
+	return opts.Coverage.AllCountry, nil
+}
@@ -0,0 +1,149 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestShipping_Validate(t *testing.T) {
+	t.Parallel()
+	cost := &ShippingCost{Description: "foo", Cost: 10}
+	tests := []struct {
+		name     string
+		shipping *Shipping
+		cat      *Category
+		wantErr  error
+	}{
+		{
+			name:     "NIL CATEGORY",
+			shipping: NewMe2Shipping(true),
+			wantErr:  ErrNilCategory,
+		},
+		{
+			name:     "UNKNOWN MODE",
+			shipping: &Shipping{Mode: "foo"},
+			cat:      &Category{},
+			wantErr:  ErrInvalidShippingMode,
+		},
+		{
+			name:     "mode NOT ALLOWED by the CATEGORY",
+			shipping: NewMe2Shipping(false),
+			cat:      &Category{ShippingModes: []ShippingMode{ShippingCustom, ShippingNotSpecified}},
+			wantErr:  ErrInvalidShippingMode,
+		},
+		{
+			name:     "mode NOT ALLOWED by the CATEGORY SETTINGS",
+			shipping: NewNotSpecifiedShipping(true),
+			cat:      &Category{Settings: &CategorySettings{ShippingModes: []ShippingMode{ShippingMe2}}},
+			wantErr:  ErrInvalidShippingMode,
+		},
+		{
+			name:     "CUSTOM WITHOUT COSTS",
+			shipping: &Shipping{Mode: ShippingCustom},
+			cat:      &Category{},
+			wantErr:  ErrNilShippingCosts,
+		},
+		{
+			name:     "CUSTOM with FREE SHIPPING",
+			shipping: &Shipping{Mode: ShippingCustom, FreeShipping: true, Costs: []*ShippingCost{cost}},
+			cat:      &Category{},
+			wantErr:  ErrInvalidFreeShipping,
+		},
+		{
+			name:     "ALLOWED mode",
+			shipping: &Shipping{Mode: ShippingCustom, Costs: []*ShippingCost{cost}},
+			cat:      &Category{ShippingModes: []ShippingMode{ShippingMe2, ShippingCustom}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.shipping.Validate(tt.cat)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("Shipping.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMeLi_FreeShippingCost(t *testing.T) {
+	t.Parallel()
+	dims := &Dimensions{Height: 10, Width: 15, Length: 20, Weight: 500}
+	tests := []struct {
+		name     string
+		sellerId int
+		query    *FreeShippingQuery
+		stub     *httpstub.Stub
+		wantCost *FreeShippingCost
+		wantErr  error
+	}{
+		{
+			name:    "NIL SELLER ID",
+			query:   &FreeShippingQuery{Dimensions: dims, ItemPrice: 100},
+			wantErr: ErrNilSellerId,
+		},
+		{
+			name:     "NIL DIMENSIONS",
+			sellerId: 1,
+			query:    &FreeShippingQuery{ItemPrice: 100},
+			wantErr:  ErrNilDimensions,
+		},
+		{
+			name:     "REMOTE returns an ERR",
+			sellerId: 1,
+			query:    &FreeShippingQuery{Dimensions: dims, ItemPrice: 100},
+			stub: &httpstub.Stub{Status: 400,
+				URL:  "/users/1/shipping_options/free",
+				Body: svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"},
+					"dimensions":   []string{"10x15x20,500"},
+					"item_price":   []string{"100"},
+					"mode":         []string{"me2"},
+				}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:     "REMOTE returns CORRECTly",
+			sellerId: 1,
+			query:    &FreeShippingQuery{Dimensions: dims, ItemPrice: 99.9, ListingTypeId: "gold_special", Condition: "new"},
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/users/1/shipping_options/free",
+				Body: map[string]interface{}{"coverage": map[string]interface{}{"all_country": &FreeShippingCost{ListCost: 350.5, CurrencyId: "ARS", BillableWeight: 600}}},
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token":    []string{"foo"},
+					"dimensions":      []string{"10x15x20,500"},
+					"item_price":      []string{"99.9"},
+					"mode":            []string{"me2"},
+					"listing_type_id": []string{"gold_special"},
+					"condition":       []string{"new"},
+				}},
+			},
+			wantCost: &FreeShippingCost{ListCost: 350.5, CurrencyId: "ARS", BillableWeight: 600},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotCost, err := ml.FreeShippingCost(tt.sellerId, tt.query)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.FreeShippingCost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantCost, gotCost); diff != "" {
+				t.Errorf("MeLi.FreeShippingCost() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
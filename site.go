@@ -0,0 +1,183 @@
+package meli
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+type CurrencyId string
+
+type Site struct {
+	Id                SiteId        `json:"id"`
+	Name              string        `json:"name"`
+	CountryId         string        `json:"country_id,omitempty"`
+	DefaultCurrencyId CurrencyId    `json:"default_currency_id,omitempty"`
+	ImmediatePayment  string        `json:"immediate_payment,omitempty"`
+	PaymentMethodIds  []string      `json:"payment_method_ids,omitempty"`
+	Currencies        []*Currency   `json:"currencies,omitempty"`
+	Settings          *SiteSettings `json:"settings,omitempty"`
+}
+
+type SiteSettings struct {
+	IdentificationTypes      []string `json:"identification_types,omitempty"`
+	TaxpayerTypes            []string `json:"taxpayer_types,omitempty"`
+	IdentificationTypesRules []struct {
+		IdentificationType string `json:"identification_type"`
+		Rules              []struct {
+			EnabledTaxpayerTypes []string `json:"enabled_taxpayer_types"`
+			BeginsWith           string   `json:"begins_with"`
+			Type                 string   `json:"type"`
+			MinLength            int      `json:"min_length"`
+			MaxLength            int      `json:"max_length"`
+		} `json:"rules"`
+	} `json:"identification_types_rules,omitempty"`
+}
+
+type Currency struct {
+	Id            CurrencyId `json:"id"`
+	Symbol        string     `json:"symbol,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	DecimalPlaces int        `json:"decimal_places,omitempty"`
+}
+
+// AllowsCurrency reports if the currency can be used on the site.
+// Notice it is only meaningful on sites retrieved with its details (e.g. by GetSite)
+func (site *Site) AllowsCurrency(id CurrencyId) bool {
+	if id == site.DefaultCurrencyId {
+		return true
+	}
+	for _, currency := range site.Currencies {
+		if currency.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSites retrieves the summary (id, name and default currency) of every site
+func (ml *MeLi) ListSites() ([]*Site, error) {
+	URL, err := ml.RouteTo("/sites", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ml.getCached(URL)
+	if err != nil {
+		return nil, err
+	}
+	sites := []*Site{}
+	err = json.Unmarshal(body, &sites)
+	if err != nil {
+		return nil, err
+	}
+	return sites, nil
+}
+
+func (ml *MeLi) GetSite(siteId SiteId) (*Site, error) {
+	if siteId == "" {
+		return nil, ErrNilSiteId
+	}
+	URL, err := ml.RouteTo("/sites/%v", nil, siteId)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ml.getCached(URL)
+	if err != nil {
+		return nil, err
+	}
+	site := &Site{}
+	err = json.Unmarshal(body, site)
+	if err != nil {
+		return nil, err
+	}
+	return site, nil
+}
+
+func (ml *MeLi) ListCurrencies() ([]*Currency, error) {
+	URL, err := ml.RouteTo("/currencies", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ml.getCached(URL)
+	if err != nil {
+		return nil, err
+	}
+	currencies := []*Currency{}
+	err = json.Unmarshal(body, &currencies)
+	if err != nil {
+		return nil, err
+	}
+	return currencies, nil
+}
+
+type CurrencyConversion struct {
+	From         CurrencyId `json:"currency_base,omitempty"`
+	To           CurrencyId `json:"currency_quote,omitempty"`
+	Ratio        float64    `json:"ratio"`
+	Rate         float64    `json:"rate"`
+	InvRate      float64    `json:"inv_rate"`
+	CreationDate time.Time  `json:"creation_date,omitempty"`
+	ValidUntil   time.Time  `json:"valid_until,omitempty"`
+}
+
+// Convert converts the given amount of the From currency to the To currency
+func (conv *CurrencyConversion) Convert(amount float64) float64 {
+	return amount * conv.Ratio
+}
+
+// ConvertCurrency retrieves the current conversion ratio between the given currencies.
+// It isn't cached, since the ratio changes over the day
+func (ml *MeLi) ConvertCurrency(from, to CurrencyId) (*CurrencyConversion, error) {
+	if from == "" || to == "" {
+		return nil, ErrNilCurrencyId
+	}
+	params := url.Values{}
+	params.Set("from", string(from))
+	params.Set("to", string(to))
+	URL, err := ml.RouteTo("/currency_conversions/search", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	conv := &CurrencyConversion{}
+	err = json.NewDecoder(resp.Body).Decode(conv)
+	if err != nil {
+		return nil, err
+	}
+	if conv.From == "" {
+		conv.From = from
+	}
+	if conv.To == "" {
+		conv.To = to
+	}
+	return conv, nil
+}
+
+// ValidateProductCurrency checks the currency of the product is allowed on its site (inferred by its category)
+func (ml *MeLi) ValidateProductCurrency(prod *Product) error {
+	if prod == nil {
+		return ErrNilProduct
+	}
+	if prod.CurrencyId == "" {
+		return ErrNilCurrencyId
+	}
+	siteId := prod.site()
+	if siteId == "" {
+		return ErrNilSiteId
+	}
+	site, err := ml.GetSite(siteId)
+	if err != nil {
+		return err
+	}
+	if !site.AllowsCurrency(prod.CurrencyId) {
+		return ErrInvalidCurrencyId
+	}
+	return nil
+}
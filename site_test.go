@@ -0,0 +1,129 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_ValidateProductCurrency(t *testing.T) {
+	t.Parallel()
+	site := &Site{Id: "MLA", DefaultCurrencyId: "ARS", Currencies: []*Currency{{Id: "ARS"}, {Id: "USD"}}}
+	tests := []struct {
+		name    string
+		prod    *Product
+		stub    *httpstub.Stub
+		wantErr error
+	}{
+		{
+			name:    "NIL CURRENCY",
+			prod:    &Product{CategoryId: "MLA1055"},
+			wantErr: ErrNilCurrencyId,
+		},
+		{
+			name:    "NIL SITE",
+			prod:    &Product{CurrencyId: "ARS"},
+			wantErr: ErrNilSiteId,
+		},
+		{
+			name:    "REMOTE returns an ERR",
+			prod:    &Product{CategoryId: "MLA1055", CurrencyId: "ARS"},
+			stub:    &httpstub.Stub{Status: 404, URL: "/sites/MLA", Body: svErrFooBar},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:    "currency NOT ALLOWED on the SITE",
+			prod:    &Product{CategoryId: "MLA1055", CurrencyId: "BRL"},
+			stub:    &httpstub.Stub{Status: 200, URL: "/sites/MLA", Body: site},
+			wantErr: ErrInvalidCurrencyId,
+		},
+		{
+			name: "currency ALLOWED on the SITE",
+			prod: &Product{CategoryId: "MLA1055", CurrencyId: "USD"},
+			stub: &httpstub.Stub{Status: 200, URL: "/sites/MLA", Body: site},
+		},
+		{
+			name: "SITE given WITHOUT CATEGORY",
+			prod: &Product{SiteId: "MLA", CurrencyId: "ARS"},
+			stub: &httpstub.Stub{Status: 200, URL: "/sites/MLA", Body: site},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			err := ml.ValidateProductCurrency(tt.prod)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ValidateProductCurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMeLi_ConvertCurrency(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		from, to CurrencyId
+		stub     *httpstub.Stub
+		wantConv *CurrencyConversion
+		wantErr  error
+	}{
+		{
+			name:    "NIL CURRENCY",
+			from:    "USD",
+			wantErr: ErrNilCurrencyId,
+		},
+		{
+			name: "REMOTE returns an ERR",
+			from: "USD",
+			to:   "ARS",
+			stub: &httpstub.Stub{Status: 400,
+				URL:     "/currency_conversions/search",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"from": []string{"USD"}, "to": []string{"ARS"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name: "REMOTE returns CORRECTly",
+			from: "USD",
+			to:   "ARS",
+			stub: &httpstub.Stub{Status: 200,
+				URL:     "/currency_conversions/search",
+				Body:    &CurrencyConversion{Ratio: 350, Rate: 350, InvRate: 0.0028},
+				Receive: httpstub.Receive{Params: url.Values{"from": []string{"USD"}, "to": []string{"ARS"}}},
+			},
+			wantConv: &CurrencyConversion{From: "USD", To: "ARS", Ratio: 350, Rate: 350, InvRate: 0.0028},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotConv, err := ml.ConvertCurrency(tt.from, tt.to)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ConvertCurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantConv, gotConv); diff != "" {
+				t.Errorf("MeLi.ConvertCurrency() mismatch (-want +got): %s", diff)
+			}
+			if gotConv != nil && gotConv.Convert(2) != 700 {
+				t.Errorf("CurrencyConversion.Convert() = %v, want %v", gotConv.Convert(2), 700)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package meli
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// StockReconciler decrements the stock of the products (or its variants) sold on each order.
+// Every order item is decremented only once, even if the order is notified several times (concurrently or not)
+type StockReconciler struct {
+	ml       *MeLi
+	ledger   StockLedger
+	statuses []OrderStatus
+
+	// prodLocks serializes the read-modify-write of the stock of each product
+	prodLocks map[ProductId]*productLock
+	lock      sync.Mutex
+}
+
+// StockLedger is a Ledger which also keeps the stock push of each claimed key until it's confirmed,
+// so a push which failed (or was interrupted by a crash) is neither lost nor repeated
+type StockLedger interface {
+	Ledger
+	// ClaimPush atomically claims the key as pending of the given push, retrieving whether this call claimed it
+	ClaimPush(key string, push *StockPush) (bool, error)
+	// Pushes retrieves the pending pushes by the key which claimed them
+	Pushes() (map[string]*StockPush, error)
+	// Confirm marks the push of the claimed key as done
+	Confirm(key string) error
+}
+
+// StockPush decrements the stock of a product (or of its variant) from the quantity it had when claimed
+type StockPush struct {
+	ProductId ProductId `json:"product_id"`
+	VariantId VariantId `json:"variant_id,omitempty"`
+	From      int       `json:"from"`
+	Quantity  int       `json:"quantity"`
+}
+
+type productLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewStockReconciler creates a reconciler which keeps the reconciled order items on the given ledger
+// (which should be durable, to avoid double-counting after a restart; defaults to an in-memory one).
+// Only the orders with one of the given statuses are reconciled (defaults to paid)
+func (ml *MeLi) NewStockReconciler(ledger StockLedger, statuses ...OrderStatus) *StockReconciler {
+	if ledger == nil {
+		ledger = NewMemoryLedger(0)
+	}
+	if len(statuses) == 0 {
+		statuses = []OrderStatus{OrderPaid}
+	}
+	return &StockReconciler{ml: ml, ledger: ledger, statuses: statuses, prodLocks: make(map[ProductId]*productLock)}
+}
+
+// HandleWebhook reconciles the notified order. It can be registered as the WebhookFunc of TopicOrders
+func (r *StockReconciler) HandleWebhook(wh *Webhook) error {
+	ord, err := r.ml.ProcessOrderWebhook(wh)
+	if err != nil {
+		return err
+	}
+	return r.Reconcile(ord)
+}
+
+// Reconcile decrements the stock of the products sold on the order, pushing them to the remote.
+// Each order item is claimed on the ledger as pending before being pushed, and confirmed once the push succeeds.
+// The pending pushes of a product are resumed before pushing it again (see resume)
+func (r *StockReconciler) Reconcile(ord *Order) error {
+	if ord == nil {
+		return ErrNilOrder
+	}
+	if !r.reconciles(ord.Status) {
+		return nil
+	}
+	idxsByProd := make(map[ProductId][]int)
+	var prodIds []ProductId
+	for i, item := range ord.OrderItems {
+		if item.Item == nil || item.Item.Id == "" {
+			continue
+		}
+		if _, ok := idxsByProd[item.Item.Id]; !ok {
+			prodIds = append(prodIds, item.Item.Id)
+		}
+		idxsByProd[item.Item.Id] = append(idxsByProd[item.Item.Id], i)
+	}
+	for _, prodId := range prodIds {
+		err := r.reconcileProduct(ord, prodId, idxsByProd[prodId])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *StockReconciler) reconciles(status OrderStatus) bool {
+	for _, s := range r.statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *StockReconciler) reconcileProduct(ord *Order, prodId ProductId, idxs []int) error {
+	unlock := r.lockProduct(prodId)
+	defer unlock()
+	pending, err := r.pendingPushes(prodId)
+	if err != nil {
+		return err
+	}
+	var unclaimed []int
+	for _, idx := range idxs {
+		seen, err := r.ledger.Seen(orderItemKey(ord, idx))
+		if err != nil {
+			return err
+		}
+		if !seen {
+			unclaimed = append(unclaimed, idx)
+		}
+	}
+	if len(pending) == 0 && len(unclaimed) == 0 {
+		return nil
+	}
+	prod, err := r.ml.GetProduct(prodId)
+	if err != nil {
+		return err
+	}
+	for _, p := range pending {
+		err = r.resume(prod, p.key, p.push)
+		if err != nil {
+			return err
+		}
+	}
+	for _, idx := range unclaimed {
+		item := ord.OrderItems[idx]
+		v := prod.variantFor(item.Item)
+		if v == nil && len(prod.Variants) > 0 {
+			return ErrVariantNotFound
+		}
+		push := &StockPush{ProductId: prodId, Quantity: item.Quantity}
+		if v != nil {
+			push.VariantId = v.Id
+		}
+		if stock := stockOf(prod, push); stock != nil {
+			push.From = *stock
+		}
+		key := orderItemKey(ord, idx)
+		claimed, err := r.ledger.ClaimPush(key, push)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		err = r.push(prod, key, push)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type pendingPush struct {
+	key  string
+	push *StockPush
+}
+
+// pendingPushes retrieves the pushes of the product which weren't confirmed, sorted by their key
+func (r *StockReconciler) pendingPushes(prodId ProductId) ([]*pendingPush, error) {
+	pushes, err := r.ledger.Pushes()
+	if err != nil {
+		return nil, err
+	}
+	var pending []*pendingPush
+	for key, push := range pushes {
+		if push.ProductId == prodId {
+			pending = append(pending, &pendingPush{key: key, push: push})
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].key < pending[j].key })
+	return pending, nil
+}
+
+// resume compares the remote stock with the one the pending push decremented from, since its failure doesn't
+// mean it wasn't applied (e.g. a timeout). It's pushed again only if the stock is still the one it had when claimed;
+// otherwise it was already applied (or the stock was set afterwards, which supersedes it) and it's just confirmed
+func (r *StockReconciler) resume(prod *Product, key string, push *StockPush) error {
+	stock := stockOf(prod, push)
+	if stock == nil || *stock != push.From {
+		return r.ledger.Confirm(key)
+	}
+	return r.push(prod, key, push)
+}
+
+// push decrements the stock of the product (or its variant), confirming it once it's put on the remote.
+// If it fails, the push is left pending to be resumed by the next attempt
+func (r *StockReconciler) push(prod *Product, key string, push *StockPush) error {
+	stock := push.From - push.Quantity
+	clampStock(&stock)
+	var err error
+	if push.VariantId != 0 {
+		_, err = r.ml.updateVariant(&Variant{Id: push.VariantId, AvailableQuantity: &stock}, prod.Id)
+	} else {
+		err = r.ml.updateProductFields(prod.Id, map[string]interface{}{"available_quantity": stock})
+	}
+	if err != nil {
+		return err
+	}
+	// Keeps the fetched product up to date, so the next pushes of it decrement from the new stock
+	if push.VariantId != 0 {
+		prod.variant(push.VariantId).AvailableQuantity = &stock
+	} else {
+		prod.AvailableQuantity = &stock
+	}
+	return r.ledger.Confirm(key)
+}
+
+// lockProduct locks the given product, retrieving the func which unlocks it
+func (r *StockReconciler) lockProduct(prodId ProductId) func() {
+	r.lock.Lock()
+	pl, ok := r.prodLocks[prodId]
+	if !ok {
+		pl = &productLock{}
+		r.prodLocks[prodId] = pl
+	}
+	pl.refs++
+	r.lock.Unlock()
+
+	pl.Lock()
+	return func() {
+		pl.Unlock()
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		pl.refs--
+		if pl.refs == 0 {
+			delete(r.prodLocks, prodId)
+		}
+	}
+}
+
+// variantFor retrieves the variant sold, matching it by its id or, lacking it, by its SKU
+func (prod *Product) variantFor(item *OrderedProduct) *Variant {
+	for _, v := range prod.Variants {
+		if item.VariationId != 0 && v.Id == item.VariationId {
+			return v
+		}
+	}
+	if item.SellerSku == "" {
+		return nil
+	}
+	for _, v := range prod.Variants {
+		if v.sku() == item.SellerSku {
+			return v
+		}
+	}
+	return nil
+}
+
+// variant retrieves the variant of the product with the given id, if any
+func (prod *Product) variant(id VariantId) *Variant {
+	for _, v := range prod.Variants {
+		if v.Id == id {
+			return v
+		}
+	}
+	return nil
+}
+
+// stockOf retrieves the stock of the product (or its variant) decremented by the push
+func stockOf(prod *Product, push *StockPush) *int {
+	if push.VariantId == 0 {
+		return prod.AvailableQuantity
+	}
+	v := prod.variant(push.VariantId)
+	if v == nil {
+		return nil
+	}
+	return v.AvailableQuantity
+}
+
+func (v *Variant) sku() string {
+	for _, attr := range v.Attributes {
+		if attr.Id == "SELLER_SKU" {
+			return attr.ValueName
+		}
+	}
+	return v.SellerCustomField
+}
+
+func clampStock(stock *int) {
+	if stock != nil && *stock < 0 {
+		*stock = 0
+	}
+}
+
+func orderItemKey(ord *Order, idx int) string {
+	return fmt.Sprintf("order|%v|%v", ord.Id, idx)
+}
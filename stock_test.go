@@ -0,0 +1,257 @@
+package meli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestStockReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+	params := url.Values{"access_token": []string{"foo"}}
+	prod := &Product{Id: "MLA1", Variants: []*Variant{
+		{Id: 5, AvailableQuantity: pointerToInt(10)},
+		{Id: 6, AvailableQuantity: pointerToInt(1), Attributes: []*Attribute{{Id: "SELLER_SKU", ValueName: "bar"}}},
+	}}
+	tests := []struct {
+		name    string
+		ord     *Order
+		stubs   []*httpstub.Stub
+		wantErr error
+	}{
+		{
+			name:    "NIL order",
+			wantErr: ErrNilOrder,
+		},
+		{
+			name: "order is NOT PAID",
+			ord: &Order{Id: 1, Status: OrderPaymentRequired, OrderItems: []*OrderItem{
+				{Item: &OrderedProduct{Id: "MLA1", VariationId: 5}, Quantity: 2},
+			}},
+		},
+		{
+			name: "variants are DECREMENTED by ID and SKU",
+			ord: &Order{Id: 1, Status: OrderPaid, OrderItems: []*OrderItem{
+				{Item: &OrderedProduct{Id: "MLA1", VariationId: 5}, Quantity: 2},
+				{Item: &OrderedProduct{Id: "MLA1", SellerSku: "bar"}, Quantity: 3},
+			}},
+			stubs: []*httpstub.Stub{
+				{Status: 200, URL: "/items/MLA1", Body: prod, Receive: httpstub.Receive{Params: params}},
+				{Status: 200, URL: "/items/MLA1/variations/5", Body: &Variant{Id: 5},
+					Receive: httpstub.Receive{Params: params, Body: JSONMarshal(t, &Variant{AvailableQuantity: pointerToInt(8)})},
+				},
+				{Status: 200, URL: "/items/MLA1/variations/6", Body: &Variant{Id: 6},
+					Receive: httpstub.Receive{Params: params, Body: JSONMarshal(t, &Variant{AvailableQuantity: pointerToInt(0)})},
+				},
+			},
+		},
+		{
+			name: "variant is NOT FOUND",
+			ord: &Order{Id: 1, Status: OrderPaid, OrderItems: []*OrderItem{
+				{Item: &OrderedProduct{Id: "MLA1", SellerSku: "baz"}, Quantity: 3},
+			}},
+			stubs: []*httpstub.Stub{
+				{Status: 200, URL: "/items/MLA1", Body: prod, Receive: httpstub.Receive{Params: params}},
+			},
+			wantErr: ErrVariantNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: tt.stubs, Client: ml}
+			cleanup := stubber.Serve(t)
+
+			r := ml.NewStockReconciler(nil)
+			err := r.Reconcile(tt.ord)
+			cleanup()
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("StockReconciler.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			// The server is already closed, so reconciling it again must not perform any request
+			err = r.Reconcile(tt.ord)
+			if err != nil {
+				t.Errorf("StockReconciler.Reconcile() DOUBLE-COUNTED the order: %v", err)
+			}
+		})
+	}
+}
+
+func TestStockReconciler_Reconcile_Product(t *testing.T) {
+	t.Parallel()
+	ord := &Order{Id: 1, Status: OrderPaid, OrderItems: []*OrderItem{
+		{Item: &OrderedProduct{Id: "MLA1"}, Quantity: 2},
+	}}
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	var lock sync.Mutex
+	var puts []string
+	ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		if req.Method != http.MethodPut {
+			// Slows down the read, so concurrent reconciles of the same order overlap
+			time.Sleep(10 * time.Millisecond)
+			resp.Body = ioutil.NopCloser(bytes.NewBufferString(`{"id":"MLA1","available_quantity":5,"seller_custom_field":"bar"}`))
+			return resp, nil
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		lock.Lock()
+		puts = append(puts, req.URL.Path+" "+string(body))
+		lock.Unlock()
+		resp.Body = ioutil.NopCloser(bytes.NewBufferString(`{}`))
+		return resp, nil
+	})})
+
+	// Re-sent notifications of the same order, processed by different workers
+	r := ml.NewStockReconciler(nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.Reconcile(ord); err != nil {
+				t.Errorf("StockReconciler.Reconcile() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	// Only the stock is put, so the rest of the product (e.g. its SKU) isn't overwritten
+	if diff := cmp.Diff([]string{`/items/MLA1 {"available_quantity":3}`}, puts); diff != "" {
+		t.Errorf("StockReconciler.Reconcile() puts mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestStockReconciler_Reconcile_Release(t *testing.T) {
+	t.Parallel()
+	ord := &Order{Id: 1, Status: OrderPaid, OrderItems: []*OrderItem{
+		{Item: &OrderedProduct{Id: "MLA1"}, Quantity: 2},
+	}}
+	ml := &MeLi{creds: &creds{Access: "foo"}}
+	stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{
+		{Status: 400, URL: "/items/MLA1", Body: svErrFooBar, Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}}},
+	}, Client: ml}
+	cleanup := stubber.Serve(t)
+	defer cleanup()
+
+	ledger := NewMemoryLedger(0)
+	r := ml.NewStockReconciler(ledger)
+	err := r.Reconcile(ord)
+	if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", svErrFooBar) {
+		t.Errorf("StockReconciler.Reconcile() error = %v, wantErr %v", err, svErrFooBar)
+	}
+	if seen, _ := ledger.Seen(orderItemKey(ord, 0)); seen {
+		t.Errorf("StockReconciler.Reconcile() did NOT RELEASE the order item which failed")
+	}
+}
+
+func TestStockReconciler_Reconcile_Pending(t *testing.T) {
+	t.Parallel()
+	ord := &Order{Id: 1, Status: OrderPaid, OrderItems: []*OrderItem{
+		{Item: &OrderedProduct{Id: "MLA1"}, Quantity: 2},
+	}}
+	tests := []struct {
+		name string
+		// applied is whether the failed push reached the remote
+		applied bool
+		// crash stops the reconciler after claiming the order item, before pushing it
+		crash    bool
+		wantPuts int
+	}{
+		{name: "push is APPLIED but FAILS (e.g. a timeout)", applied: true, wantPuts: 1},
+		{name: "push is NOT APPLIED", wantPuts: 2},
+		{name: "CRASH after the claim, before the push", crash: true, wantPuts: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir, err := ioutil.TempDir("", "meli")
+			if err != nil {
+				t.Fatalf("couldn't create temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			filename := filepath.Join(dir, "ledger")
+
+			var lock sync.Mutex
+			stock, puts, failing := 5, 0, !tt.crash
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			ml.SetClient(http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+				if req.Method != http.MethodPut {
+					resp.Body = ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"id":"MLA1","available_quantity":%v}`, stock)))
+					return resp, nil
+				}
+				puts++
+				prod := &Product{}
+				if err := json.NewDecoder(req.Body).Decode(prod); err != nil {
+					return nil, err
+				}
+				if failing {
+					failing = false
+					if tt.applied {
+						stock = *prod.AvailableQuantity
+					}
+					return nil, errors.New("timeout")
+				}
+				stock = *prod.AvailableQuantity
+				resp.Body = ioutil.NopCloser(bytes.NewBufferString(`{}`))
+				return resp, nil
+			})})
+
+			ledger, err := NewFileLedger(filename)
+			if err != nil {
+				t.Fatalf("NewFileLedger() error = %v", err)
+			}
+			if tt.crash {
+				claimed, err := ledger.ClaimPush(orderItemKey(ord, 0), &StockPush{ProductId: "MLA1", From: 5, Quantity: 2})
+				if !claimed || err != nil {
+					t.Fatalf("FileLedger.ClaimPush() = %v, %v, want %v", claimed, err, true)
+				}
+			} else if err := ml.NewStockReconciler(ledger).Reconcile(ord); err == nil {
+				t.Fatalf("StockReconciler.Reconcile() error = nil, want the one of the failed push")
+			}
+			// Restarts the reconciler, which resumes the pending push
+			if err := ledger.Close(); err != nil {
+				t.Fatalf("FileLedger.Close() error = %v", err)
+			}
+			reopened, err := NewFileLedger(filename)
+			if err != nil {
+				t.Fatalf("NewFileLedger() on reopen error = %v", err)
+			}
+			defer reopened.Close()
+			r := ml.NewStockReconciler(reopened)
+			for i := 0; i < 2; i++ {
+				if err := r.Reconcile(ord); err != nil {
+					t.Fatalf("StockReconciler.Reconcile() on retry error = %v", err)
+				}
+			}
+			if stock != 3 || puts != tt.wantPuts {
+				t.Errorf("StockReconciler.Reconcile() remote stock = %v after %v puts, want %v after %v", stock, puts, 3, tt.wantPuts)
+			}
+			if pushes, _ := reopened.Pushes(); len(pushes) != 0 {
+				t.Errorf("StockReconciler.Reconcile() left pushes PENDING: %v", pushes)
+			}
+		})
+	}
+}
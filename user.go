@@ -0,0 +1,198 @@
+package meli
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+type User struct {
+	Id               int               `json:"id"`
+	Nickname         string            `json:"nickname,omitempty"`
+	FirstName        string            `json:"first_name,omitempty"`
+	LastName         string            `json:"last_name,omitempty"`
+	Email            string            `json:"email,omitempty"`
+	CountryId        string            `json:"country_id,omitempty"`
+	SiteId           SiteId            `json:"site_id,omitempty"`
+	UserType         string            `json:"user_type,omitempty"`
+	Points           int               `json:"points,omitempty"`
+	Permalink        string            `json:"permalink,omitempty"`
+	RegistrationDate time.Time         `json:"registration_date,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
+	Status           *UserStatus       `json:"status,omitempty"`
+	SellerReputation *SellerReputation `json:"seller_reputation,omitempty"`
+}
+
+type UserStatus struct {
+	SiteStatus string `json:"site_status,omitempty"` // e.g. active, deactive
+	List       *struct {
+		Allow bool `json:"allow"`
+	} `json:"list,omitempty"`
+	Sell *struct {
+		Allow bool `json:"allow"`
+	} `json:"sell,omitempty"`
+}
+
+type SellerReputation struct {
+	LevelId           ReputationLevel         `json:"level_id,omitempty"`
+	PowerSellerStatus string                  `json:"power_seller_status,omitempty"` // e.g. silver, gold, platinum
+	Transactions      *ReputationTransactions `json:"transactions,omitempty"`
+	Metrics           *ReputationMetrics      `json:"metrics,omitempty"`
+}
+
+// ReputationLevel is the color of the thermometer of the seller, prefixed by its rank (e.g. 5_green)
+type ReputationLevel string
+
+const (
+	ReputationRed        ReputationLevel = "1_red"
+	ReputationOrange     ReputationLevel = "2_orange"
+	ReputationYellow     ReputationLevel = "3_yellow"
+	ReputationLightGreen ReputationLevel = "4_light_green"
+	ReputationGreen      ReputationLevel = "5_green"
+)
+
+// Rank retrieves the rank of the level, from 1 (red) to 5 (green), or 0 if the seller has no level yet
+func (level ReputationLevel) Rank() int {
+	if level == "" {
+		return 0
+	}
+	rank, err := strconv.Atoi(string(level[0]))
+	if err != nil {
+		return 0
+	}
+	return rank
+}
+
+type ReputationTransactions struct {
+	Period    string `json:"period,omitempty"`
+	Total     int    `json:"total"`
+	Completed int    `json:"completed"`
+	Canceled  int    `json:"canceled"`
+	Ratings   struct {
+		Positive float64 `json:"positive"`
+		Negative float64 `json:"negative"`
+		Neutral  float64 `json:"neutral"`
+	} `json:"ratings"`
+}
+
+type ReputationMetrics struct {
+	Sales *struct {
+		Period    string `json:"period,omitempty"`
+		Completed int    `json:"completed"`
+	} `json:"sales,omitempty"`
+	Claims              *ReputationMetric `json:"claims,omitempty"`
+	DelayedHandlingTime *ReputationMetric `json:"delayed_handling_time,omitempty"`
+	Cancellations       *ReputationMetric `json:"cancellations,omitempty"`
+}
+
+type ReputationMetric struct {
+	Period string  `json:"period,omitempty"`
+	Rate   float64 `json:"rate"`
+	Value  int     `json:"value"`
+}
+
+// AccountPolicy are the min health conditions an account must meet to be automated.
+// The zero values aren't checked
+type AccountPolicy struct {
+	MinLevel               int // From 1 (red) to 5 (green)
+	MaxClaimsRate          float64
+	MaxCancellationsRate   float64
+	MaxDelayedHandlingRate float64
+}
+
+// CheckHealth checks the user is active and its reputation meets the given policy
+func (u *User) CheckHealth(policy *AccountPolicy) error {
+	if u.Status != nil && u.Status.SiteStatus != "" && u.Status.SiteStatus != "active" {
+		return ErrInactiveUser
+	}
+	if policy == nil {
+		return nil
+	}
+	rep := u.SellerReputation
+	if rep == nil {
+		rep = &SellerReputation{}
+	}
+	if rep.LevelId.Rank() < policy.MinLevel {
+		return ErrLowReputation
+	}
+	if rep.Metrics == nil {
+		return nil
+	}
+	if exceedsRate(rep.Metrics.Claims, policy.MaxClaimsRate) {
+		return ErrClaimsRateExceeded
+	}
+	if exceedsRate(rep.Metrics.Cancellations, policy.MaxCancellationsRate) {
+		return ErrCancellationsRateExceeded
+	}
+	if exceedsRate(rep.Metrics.DelayedHandlingTime, policy.MaxDelayedHandlingRate) {
+		return ErrDelayedHandlingRateExceeded
+	}
+	return nil
+}
+
+func exceedsRate(metric *ReputationMetric, max float64) bool {
+	return max > 0 && metric != nil && metric.Rate > max
+}
+
+func (ml *MeLi) GetUser(id int) (*User, error) {
+	if id == 0 {
+		return nil, ErrNilUserId
+	}
+	return ml.getUser(id)
+}
+
+// GetMe retrieves the user who granted the access token. It's cached until the credentials are set again
+func (ml *MeLi) GetMe() (*User, error) {
+	if ml.creds == nil {
+		return nil, ErrNilCredentials
+	}
+	ml.meLock.Lock()
+	defer ml.meLock.Unlock()
+	if ml.creds.me != nil {
+		return ml.creds.me, nil
+	}
+	me, err := ml.getUser("me")
+	if err != nil {
+		return nil, err
+	}
+	ml.creds.me = me
+	ml.creds.UserId = me.Id
+	return me, nil
+}
+
+// SiteId retrieves the site of the authenticated seller, which is the default one to operate on
+func (ml *MeLi) SiteId() (SiteId, error) {
+	me, err := ml.GetMe()
+	if err != nil {
+		return "", err
+	}
+	if me.SiteId == "" {
+		return "", ErrNilSiteId
+	}
+	return me.SiteId, nil
+}
+
+func (ml *MeLi) getUser(id interface{}) (*User, error) {
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/users/%v", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	u := &User{}
+	err = json.NewDecoder(resp.Body).Decode(u)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
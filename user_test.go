@@ -0,0 +1,148 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_GetMe(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		creds      *creds
+		stub       *httpstub.Stub
+		wantUser   *User
+		wantSiteId SiteId
+		wantErr    error
+	}{
+		{
+			name:    "NIL CREDENTIALS",
+			wantErr: ErrNilCredentials,
+		},
+		{
+			name:    "NIL ACCESS TOKEN",
+			creds:   &creds{},
+			wantErr: ErrNilAccessToken,
+		},
+		{
+			name:  "REMOTE returns an ERR",
+			creds: &creds{Access: "foo"},
+			stub: &httpstub.Stub{Status: 401,
+				URL:     "/users/me",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name:  "REMOTE returns CORRECTly",
+			creds: &creds{Access: "foo"},
+			stub: &httpstub.Stub{Status: 200,
+				URL: "/users/me",
+				Body: &User{Id: 1, Nickname: "bar", SiteId: "MLA",
+					SellerReputation: &SellerReputation{LevelId: ReputationGreen},
+				},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}}},
+			},
+			wantUser: &User{Id: 1, Nickname: "bar", SiteId: "MLA",
+				SellerReputation: &SellerReputation{LevelId: ReputationGreen},
+			},
+			wantSiteId: "MLA",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: tt.creds}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+
+			gotUser, err := ml.GetMe()
+			cleanup()
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.GetMe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantUser, gotUser); diff != "" {
+				t.Errorf("MeLi.GetMe() mismatch (-want +got): %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			// The server is already closed, so the site must be derived from the cached user
+			gotSiteId, err := ml.SiteId()
+			if err != nil {
+				t.Errorf("MeLi.SiteId() error = %v", err)
+			}
+			if gotSiteId != tt.wantSiteId {
+				t.Errorf("MeLi.SiteId() = %v, want %v", gotSiteId, tt.wantSiteId)
+			}
+		})
+	}
+}
+
+func TestUser_CheckHealth(t *testing.T) {
+	t.Parallel()
+	policy := &AccountPolicy{MinLevel: 4, MaxClaimsRate: 0.02, MaxCancellationsRate: 0.02}
+	tests := []struct {
+		name    string
+		user    *User
+		policy  *AccountPolicy
+		wantErr error
+	}{
+		{
+			name:    "user is NOT ACTIVE",
+			user:    &User{Status: &UserStatus{SiteStatus: "deactive"}},
+			policy:  policy,
+			wantErr: ErrInactiveUser,
+		},
+		{
+			name:    "user WITHOUT REPUTATION",
+			user:    &User{},
+			policy:  policy,
+			wantErr: ErrLowReputation,
+		},
+		{
+			name:    "LOW REPUTATION",
+			user:    &User{SellerReputation: &SellerReputation{LevelId: ReputationYellow}},
+			policy:  policy,
+			wantErr: ErrLowReputation,
+		},
+		{
+			name: "CLAIMS RATE EXCEEDED",
+			user: &User{SellerReputation: &SellerReputation{LevelId: ReputationGreen,
+				Metrics: &ReputationMetrics{Claims: &ReputationMetric{Rate: 0.05}},
+			}},
+			policy:  policy,
+			wantErr: ErrClaimsRateExceeded,
+		},
+		{
+			name: "DELAYED HANDLING RATE is NOT CHECKED",
+			user: &User{SellerReputation: &SellerReputation{LevelId: ReputationLightGreen,
+				Metrics: &ReputationMetrics{
+					Claims:              &ReputationMetric{Rate: 0.01},
+					DelayedHandlingTime: &ReputationMetric{Rate: 0.5},
+				},
+			}},
+			policy: policy,
+		},
+		{
+			name: "NIL POLICY",
+			user: &User{Status: &UserStatus{SiteStatus: "active"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.user.CheckHealth(tt.policy)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("User.CheckHealth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
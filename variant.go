@@ -16,6 +16,8 @@ type Variant struct {
 	SaleTerms             []*SaleTerm  `json:"sale_terms,omitempty"`
 	PictureIds            []string     `json:"picture_ids,omitempty"`
 	CatalogProductId      interface{}  `json:"catalog_product_id,omitempty"`
+	Attributes            []*Attribute `json:"attributes,omitempty"`
+	SellerCustomField     string       `json:"seller_custom_field,omitempty"`
 }
 
 type VariantId int
@@ -0,0 +1,173 @@
+package meli
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxVisitsItems is the max quantity of items whose visits can be retrieved at once
+const maxVisitsItems = 50
+
+// ItemVisits retrieves the total visits of each of the given items
+func (ml *MeLi) ItemVisits(ids ...ProductId) (map[ProductId]int, error) {
+	strIds, err := visitsIds(ids)
+	if err != nil {
+		return nil, err
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("ids", strIds)
+	URL, err := ml.RouteTo("/visits/items", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	visits := make(map[ProductId]int)
+	err = json.NewDecoder(resp.Body).Decode(&visits)
+	if err != nil {
+		return nil, err
+	}
+	return visits, nil
+}
+
+func visitsIds(ids []ProductId) (string, error) {
+	if len(ids) == 0 || len(ids) > maxVisitsItems {
+		return "", ErrInvalidMultigetQuantity
+	}
+	strIds := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return "", ErrNilProductId
+		}
+		strIds = append(strIds, string(id))
+	}
+	return strings.Join(strIds, ","), nil
+}
+
+type VisitsUnit string
+
+const (
+	VisitsByDay  VisitsUnit = "day"
+	VisitsByHour VisitsUnit = "hour"
+)
+
+// VisitsWindow are the last units of time whose visits are wanted, ending at the given date (defaults to today)
+type VisitsWindow struct {
+	Last   int
+	Unit   VisitsUnit
+	Ending time.Time
+}
+
+// VisitsSeries are the visits of an item on a window, bucketed by its unit
+type VisitsSeries struct {
+	ItemId      ProductId       `json:"item_id"`
+	TotalVisits int             `json:"total_visits"`
+	DateFrom    time.Time       `json:"date_from"`
+	DateTo      time.Time       `json:"date_to"`
+	Last        int             `json:"last"`
+	Unit        VisitsUnit      `json:"unit"`
+	Results     []*VisitsBucket `json:"results"`
+}
+
+type VisitsBucket struct {
+	Date  time.Time `json:"date"`
+	Total int       `json:"total"`
+}
+
+func (ml *MeLi) ItemVisitsSeries(id ProductId, window *VisitsWindow) (*VisitsSeries, error) {
+	if id == "" {
+		return nil, ErrNilProductId
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	err = window.setParams(params)
+	if err != nil {
+		return nil, err
+	}
+	URL, err := ml.RouteTo("/items/%v/visits/time_window", params, id)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	series := &VisitsSeries{}
+	err = json.NewDecoder(resp.Body).Decode(series)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (ml *MeLi) ItemsVisitsSeries(window *VisitsWindow, ids ...ProductId) ([]*VisitsSeries, error) {
+	strIds, err := visitsIds(ids)
+	if err != nil {
+		return nil, err
+	}
+	params, err := ml.paramsWithToken()
+	if err != nil {
+		return nil, err
+	}
+	err = window.setParams(params)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("ids", strIds)
+	URL, err := ml.RouteTo("/items/visits/time_window", params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ml.Get(URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errFromReader(resp.Body)
+	}
+	var series []*VisitsSeries
+	err = json.NewDecoder(resp.Body).Decode(&series)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (window *VisitsWindow) setParams(params url.Values) error {
+	if window == nil || window.Last <= 0 || (window.Unit != VisitsByDay && window.Unit != VisitsByHour) {
+		return ErrInvalidVisitsWindow
+	}
+	params.Set("last", strconv.Itoa(window.Last))
+	params.Set("unit", string(window.Unit))
+	if !window.Ending.IsZero() {
+		params.Set("ending", window.Ending.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// Conversion is the ratio of the sold units of the product per visit, or 0 if it has no visits
+func (prod *Product) Conversion(visits int) float64 {
+	if visits <= 0 {
+		return 0
+	}
+	return float64(prod.SoldQuantity) / float64(visits)
+}
@@ -0,0 +1,137 @@
+package meli
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sebach1/httpstub"
+)
+
+func TestMeLi_ItemVisits(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		ids        []ProductId
+		stub       *httpstub.Stub
+		wantVisits map[ProductId]int
+		wantErr    error
+	}{
+		{
+			name:    "NO ITEMS",
+			wantErr: ErrInvalidMultigetQuantity,
+		},
+		{
+			name:    "NIL ITEM ID",
+			ids:     []ProductId{"MLA1", ""},
+			wantErr: ErrNilProductId,
+		},
+		{
+			name: "REMOTE returns an ERR",
+			ids:  []ProductId{"MLA1"},
+			stub: &httpstub.Stub{Status: 400,
+				URL:     "/visits/items",
+				Body:    svErrFooBar,
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}, "ids": []string{"MLA1"}}},
+			},
+			wantErr: svErrFooBar,
+		},
+		{
+			name: "REMOTE returns CORRECTly",
+			ids:  []ProductId{"MLA1", "MLA2"},
+			stub: &httpstub.Stub{Status: 200,
+				URL:     "/visits/items",
+				Body:    map[string]int{"MLA1": 10, "MLA2": 0},
+				Receive: httpstub.Receive{Params: url.Values{"access_token": []string{"foo"}, "ids": []string{"MLA1,MLA2"}}},
+			},
+			wantVisits: map[ProductId]int{"MLA1": 10, "MLA2": 0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotVisits, err := ml.ItemVisits(tt.ids...)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ItemVisits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantVisits, gotVisits); diff != "" {
+				t.Errorf("MeLi.ItemVisits() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMeLi_ItemVisitsSeries(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	series := &VisitsSeries{ItemId: "MLA1", TotalVisits: 5, Last: 2, Unit: VisitsByDay,
+		Results: []*VisitsBucket{{Date: day.AddDate(0, 0, -1), Total: 2}, {Date: day, Total: 3}},
+	}
+	tests := []struct {
+		name       string
+		id         ProductId
+		window     *VisitsWindow
+		stub       *httpstub.Stub
+		wantSeries *VisitsSeries
+		wantErr    error
+	}{
+		{
+			name:    "NIL ITEM ID",
+			window:  &VisitsWindow{Last: 2, Unit: VisitsByDay},
+			wantErr: ErrNilProductId,
+		},
+		{
+			name:    "INVALID UNIT",
+			id:      "MLA1",
+			window:  &VisitsWindow{Last: 2, Unit: "week"},
+			wantErr: ErrInvalidVisitsWindow,
+		},
+		{
+			name:    "NIL WINDOW",
+			id:      "MLA1",
+			wantErr: ErrInvalidVisitsWindow,
+		},
+		{
+			name:   "REMOTE returns CORRECTly",
+			id:     "MLA1",
+			window: &VisitsWindow{Last: 2, Unit: VisitsByDay, Ending: day},
+			stub: &httpstub.Stub{Status: 200,
+				URL:  "/items/MLA1/visits/time_window",
+				Body: series,
+				Receive: httpstub.Receive{Params: url.Values{
+					"access_token": []string{"foo"},
+					"last":         []string{"2"},
+					"unit":         []string{"day"},
+					"ending":       []string{"2020-01-02"},
+				}},
+			},
+			wantSeries: series,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: &creds{Access: "foo"}}
+			stubber := httpstub.Stubber{Stubs: []*httpstub.Stub{tt.stub}, Client: ml}
+			cleanup := stubber.Serve(t)
+			defer cleanup()
+
+			gotSeries, err := ml.ItemVisitsSeries(tt.id, tt.window)
+			if fmt.Sprintf("%v", tt.wantErr) != fmt.Sprintf("%v", err) {
+				t.Errorf("MeLi.ItemVisitsSeries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantSeries, gotSeries); diff != "" {
+				t.Errorf("MeLi.ItemVisitsSeries() mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package meli
 
 import (
+	"encoding/json"
+	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 type Webhook struct {
 	Resource      string `json:"resource,omitempty"`
 	UserId        int    `json:"user_id,omitempty"`
-	Topic         string `json:"topic,omitempty"`
+	Topic         Topic  `json:"topic,omitempty"`
 	ApplicationId int64  `json:"application_id,omitempty"`
 	Attempts      int    `json:"attempts,omitempty"`
 
@@ -16,14 +20,446 @@ type Webhook struct {
 	Received time.Time `json:"received,omitempty"`
 }
 
-func (ml *MeLi) ProcessProductWebhook(wh *Webhook) (*Product, error) {
-	return ml.GetProduct(ProductId(wh.ResourceID()))
+type Topic string
+
+const (
+	TopicItems     Topic = "items"
+	TopicOrders    Topic = "orders_v2"
+	TopicQuestions Topic = "questions"
+	TopicPayments  Topic = "payments"
+	TopicShipments Topic = "shipments"
+	TopicMessages  Topic = "messages"
+	TopicClaims    Topic = "claims"
+)
+
+type ResourceKind string
+
+const (
+	ResourceItems     ResourceKind = "items"
+	ResourceOrders    ResourceKind = "orders"
+	ResourceQuestions ResourceKind = "questions"
+	ResourcePayments  ResourceKind = "collections"
+	ResourceShipments ResourceKind = "shipments"
+	ResourceMessages  ResourceKind = "messages"
+	ResourceClaims    ResourceKind = "claims"
+)
+
+// postPurchasePrefix prefixes the resources of the post-purchase API (e.g. /post-purchase/v1/claims/123),
+// which is trimmed so they're parsed as the rest, and restored by Resource.String
+const postPurchasePrefix = "/post-purchase/v1"
+
+// Resource is the parsed form of the resource of a notification.
+// For example, /items/MLA123/variations/456 is parsed onto {items MLA123 variations 456}
+type Resource struct {
+	Kind  ResourceKind `json:"kind,omitempty"`
+	Id    string       `json:"id,omitempty"`
+	Sub   string       `json:"sub,omitempty"`
+	SubId string       `json:"sub_id,omitempty"`
+}
+
+// ParseResource parses a resource path. In case of not being a path (e.g. the bare id
+// sent on the messages topic), the whole string is considered to be its id
+func ParseResource(s string) (*Resource, error) {
+	s = strings.TrimSpace(s)
+	if idx := strings.Index(s, "?"); idx != -1 {
+		s = s[:idx]
+	}
+	s = strings.TrimPrefix(s, postPurchasePrefix)
+	if s == "" {
+		return nil, ErrInvalidResource
+	}
+	if !strings.Contains(s, "/") {
+		return &Resource{Id: s}, nil
+	}
+	parts := strings.Split(strings.Trim(s, "/"), "/")
+	if len(parts) < 2 || len(parts) > 4 {
+		return nil, ErrInvalidResource
+	}
+	res := &Resource{Kind: ResourceKind(parts[0]), Id: parts[1]}
+	if len(parts) > 2 {
+		res.Sub = parts[2]
+	}
+	if len(parts) > 3 {
+		res.SubId = parts[3]
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, ErrInvalidResource
+		}
+	}
+	return res, nil
+}
+
+func (res *Resource) String() string {
+	if res.Kind == "" {
+		return res.Id
+	}
+	s := "/" + string(res.Kind) + "/" + res.Id
+	if res.Sub != "" {
+		s += "/" + res.Sub
+	}
+	if res.SubId != "" {
+		s += "/" + res.SubId
+	}
+	if res.Kind == ResourceClaims {
+		s = postPurchasePrefix + s
+	}
+	return s
 }
 
+// ParseResource parses the resource of the notification, inferring its kind by the topic when it's a bare id
+func (wh *Webhook) ParseResource() (*Resource, error) {
+	res, err := ParseResource(wh.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if res.Kind == "" {
+		switch wh.Topic {
+		case TopicMessages:
+			res.Kind = ResourceMessages
+		case TopicClaims:
+			res.Kind = ResourceClaims
+		}
+	}
+	return res, nil
+}
+
+// ResourceID retrieves the id of the notified resource (e.g. MLA123 for /items/MLA123)
 func (wh *Webhook) ResourceID() string {
-	idx := strings.LastIndex(wh.Resource, "/")
-	if idx == -1 {
+	res, err := wh.ParseResource()
+	if err != nil {
 		return ""
 	}
-	return wh.Resource[0:idx]
+	return res.Id
+}
+
+func (wh *Webhook) resourceIdOf(kind ResourceKind) (string, error) {
+	if wh == nil {
+		return "", ErrNilWebhook
+	}
+	res, err := wh.ParseResource()
+	if err != nil {
+		return "", err
+	}
+	if res.Kind != kind {
+		return "", ErrInvalidResource
+	}
+	return res.Id, nil
+}
+
+func (ml *MeLi) ProcessProductWebhook(wh *Webhook) (*Product, error) {
+	id, err := wh.resourceIdOf(ResourceItems)
+	if err != nil {
+		return nil, err
+	}
+	return ml.GetProduct(ProductId(id))
+}
+
+// WebhookFunc processes the notifications of a topic
+type WebhookFunc func(wh *Webhook) error
+
+// WebhookHandler is the http.Handler which receives the notifications sent by MeLi.
+// It answers as soon as the notification is decoded, validated and enqueued, while its workers
+// dispatch them to the func registered for its topic (e.g. items, orders_v2, questions, payments, shipments, messages, claims).
+// Each notification is processed once, even if it's re-sent (see Webhook.Key)
+type WebhookHandler struct {
+	// OnError is called with the notifications whose processing failed after every retry (optional)
+	OnError func(wh *Webhook, err error)
+
+	ml         *MeLi
+	handlers   map[Topic]WebhookFunc
+	queue      WebhookQueue
+	ledger     Ledger
+	maxRetries int
+	backoff    time.Duration
+
+	// unbuffered is the queue of the handlers without buffer, which only accept the notifications
+	// the idle workers can take at once
+	unbuffered *MemoryWebhookQueue
+	idle       int
+	idleLock   sync.Mutex
+
+	processing map[string]bool
+	notify     chan struct{}
+	done       chan struct{}
+	closed     bool
+	wg         sync.WaitGroup
+	lock       sync.RWMutex
+}
+
+type WebhookHandlerOptions struct {
+	Workers int
+	// Queue stores the pending notifications (defaults to an unbounded in-memory queue)
+	Queue WebhookQueue
+	// Ledger stores the processed notifications (defaults to an in-memory ledger of a day)
+	Ledger Ledger
+	// MaxRetries is the quantity of times a failed notification is re-processed
+	MaxRetries int
+	// Backoff is the wait before the first retry, which is doubled on each of the next ones (defaults to 1s)
+	Backoff time.Duration
+}
+
+// NewWebhookHandler starts the given quantity of workers, which will process up to bufferSize pending notifications.
+// Once the buffer is full, the notifications are rejected so MeLi retries them later.
+// Without buffer (bufferSize <= 0), they're only accepted if there's an idle worker to take them.
+// For an unbounded buffer, use NewWebhookHandlerWithOptions without a Queue
+func (ml *MeLi) NewWebhookHandler(workers, bufferSize int) *WebhookHandler {
+	if bufferSize <= 0 {
+		queue := NewMemoryWebhookQueue(0)
+		return ml.newWebhookHandler(&WebhookHandlerOptions{Workers: workers, Queue: queue}, queue)
+	}
+	return ml.NewWebhookHandlerWithOptions(&WebhookHandlerOptions{Workers: workers, Queue: NewMemoryWebhookQueue(bufferSize)})
+}
+
+func (ml *MeLi) NewWebhookHandlerWithOptions(opts *WebhookHandlerOptions) *WebhookHandler {
+	return ml.newWebhookHandler(opts, nil)
+}
+
+func (ml *MeLi) newWebhookHandler(opts *WebhookHandlerOptions, unbuffered *MemoryWebhookQueue) *WebhookHandler {
+	if opts == nil {
+		opts = &WebhookHandlerOptions{}
+	}
+	h := &WebhookHandler{
+		ml:         ml,
+		handlers:   make(map[Topic]WebhookFunc),
+		queue:      opts.Queue,
+		ledger:     opts.Ledger,
+		maxRetries: opts.MaxRetries,
+		backoff:    opts.Backoff,
+		unbuffered: unbuffered,
+		processing: make(map[string]bool),
+		notify:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	if h.queue == nil {
+		h.queue = NewMemoryWebhookQueue(0)
+	}
+	if h.ledger == nil {
+		h.ledger = NewMemoryLedger(24 * time.Hour)
+	}
+	if h.backoff <= 0 {
+		h.backoff = time.Second
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		h.wg.Add(1)
+		go h.work()
+	}
+	return h
+}
+
+// Handle registers the func which processes the notifications of the given topic
+func (h *WebhookHandler) Handle(topic Topic, fn WebhookFunc) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.handlers[topic] = fn
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	wh := &Webhook{}
+	err := json.NewDecoder(r.Body).Decode(wh)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = h.validate(wh)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if wh.Received.IsZero() {
+		wh.Received = time.Now()
+	}
+	err = h.Enqueue(wh)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case ErrQueueFull, ErrClosedWebhookHandler:
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *WebhookHandler) validate(wh *Webhook) error {
+	if wh.Resource == "" || wh.Topic == "" {
+		return ErrInvalidWebhook
+	}
+	if h.ml == nil || h.ml.creds == nil || h.ml.creds.ApplicationId == "" {
+		return nil
+	}
+	if strconv.FormatInt(wh.ApplicationId, 10) != string(h.ml.creds.ApplicationId) {
+		return ErrInvalidApplicationId
+	}
+	return nil
+}
+
+// Enqueue adds the notification to be processed by the workers, unless it was already processed
+func (h *WebhookHandler) Enqueue(wh *Webhook) error {
+	_, err := h.enqueue(wh)
+	return err
+}
+
+// enqueue is the same as Enqueue, also retrieving whether the notification was enqueued (or skipped as processed)
+func (h *WebhookHandler) enqueue(wh *Webhook) (bool, error) {
+	if wh == nil {
+		return false, ErrNilWebhook
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if h.closed {
+		return false, ErrClosedWebhookHandler
+	}
+	seen, err := h.ledger.Seen(wh.Key())
+	if err != nil {
+		return false, err
+	}
+	if seen {
+		return false, nil
+	}
+	if h.unbuffered != nil {
+		h.idleLock.Lock()
+		defer h.idleLock.Unlock()
+		if h.idle <= h.unbuffered.Len() {
+			return false, ErrQueueFull
+		}
+	}
+	err = h.queue.Push(wh)
+	if err != nil {
+		return false, err
+	}
+	h.wake()
+	return true, nil
+}
+
+func (h *WebhookHandler) setIdle(delta int) {
+	h.idleLock.Lock()
+	defer h.idleLock.Unlock()
+	h.idle += delta
+}
+
+func (h *WebhookHandler) wake() {
+	select {
+	case h.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (h *WebhookHandler) work() {
+	defer h.wg.Done()
+	for {
+		wh, err := h.queue.Pop()
+		if err != nil {
+			if err != ErrEmptyQueue && h.OnError != nil {
+				h.OnError(nil, err)
+			}
+			h.setIdle(1)
+			select {
+			case <-h.notify:
+				h.setIdle(-1)
+				continue
+			case <-h.done:
+				h.setIdle(-1)
+				return
+			}
+		}
+		h.wake() // Another worker can take the next one meanwhile
+		h.process(wh)
+	}
+}
+
+func (h *WebhookHandler) process(wh *Webhook) {
+	key := wh.Key()
+	if !h.startProcessing(key) {
+		// A re-sent attempt of the one another worker is processing. Only this attempt is acked,
+		// so the original one is still retrieved again by durable queues if that worker crashes
+		h.ack(wh)
+		return
+	}
+	defer h.finishProcessing(key)
+
+	seen, err := h.ledger.Seen(key)
+	if err == nil && seen {
+		h.ack(wh)
+		return
+	}
+	backoff := h.backoff
+	for retry := 0; ; retry++ {
+		err = h.Dispatch(wh)
+		if err == nil || err == ErrUnhandledTopic || retry >= h.maxRetries {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-h.done:
+			return // Left unacked, so durable queues retrieve it again after a restart
+		}
+	}
+	if err != nil {
+		if h.OnError != nil {
+			h.OnError(wh, err)
+		}
+	} else if markErr := h.ledger.Mark(key); markErr != nil && h.OnError != nil {
+		h.OnError(wh, markErr)
+	}
+	h.ack(wh)
+}
+
+func (h *WebhookHandler) ack(wh *Webhook) {
+	err := h.queue.Ack(wh)
+	if err != nil && h.OnError != nil {
+		h.OnError(wh, err)
+	}
+}
+
+func (h *WebhookHandler) startProcessing(key string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.processing[key] {
+		return false
+	}
+	h.processing[key] = true
+	return true
+}
+
+func (h *WebhookHandler) finishProcessing(key string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.processing, key)
+}
+
+// Dispatch synchronously processes the notification with the func registered for its topic
+func (h *WebhookHandler) Dispatch(wh *Webhook) error {
+	if wh == nil {
+		return ErrNilWebhook
+	}
+	h.lock.RLock()
+	fn, ok := h.handlers[wh.Topic]
+	h.lock.RUnlock()
+	if !ok {
+		return ErrUnhandledTopic
+	}
+	return fn(wh)
+}
+
+// Close stops receiving notifications, waiting for the pending ones to be processed.
+// The ones being retried are left on the queue
+func (h *WebhookHandler) Close() {
+	h.lock.Lock()
+	if h.closed {
+		h.lock.Unlock()
+		return
+	}
+	h.closed = true
+	close(h.done)
+	h.lock.Unlock()
+	h.wg.Wait()
 }
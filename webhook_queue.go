@@ -0,0 +1,554 @@
+package meli
+
+import (
+	"bufio"
+	"container/list"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookQueue is the storage of the notifications pending to be processed
+type WebhookQueue interface {
+	// Push enqueues the notification, erring with ErrQueueFull in case it can't hold more
+	Push(wh *Webhook) error
+	// Pop retrieves the next pending notification, or ErrEmptyQueue
+	Pop() (*Webhook, error)
+	// Ack marks the popped notification as done (the very one retrieved by Pop, not the equal ones also pushed).
+	// Durable queues retrieve again the unacked ones after a restart
+	Ack(wh *Webhook) error
+}
+
+// Ledger keeps the keys already processed, so each one is processed only once
+type Ledger interface {
+	Seen(key string) (bool, error)
+	Mark(key string) error
+	// Claim atomically marks the key unless it was already marked, retrieving whether this call marked it.
+	// Concurrent claims of the same key are won by only one of them
+	Claim(key string) (bool, error)
+	// Release unmarks a claimed key whose processing failed, so it can be claimed again
+	Release(key string) error
+}
+
+// Key identifies a notification, being shared by its re-sent attempts
+func (wh *Webhook) Key() string {
+	return string(wh.Topic) + "|" + wh.Resource + "|" + wh.Sent.UTC().Format(time.RFC3339Nano)
+}
+
+// MemoryWebhookQueue is a non-durable queue, losing its pending notifications on crash
+type MemoryWebhookQueue struct {
+	size    int
+	pending []*Webhook
+	lock    sync.Mutex
+}
+
+// NewMemoryWebhookQueue creates a queue which holds up to size notifications (unbounded if size <= 0)
+func NewMemoryWebhookQueue(size int) *MemoryWebhookQueue {
+	return &MemoryWebhookQueue{size: size}
+}
+
+func (q *MemoryWebhookQueue) Push(wh *Webhook) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.size > 0 && len(q.pending) >= q.size {
+		return ErrQueueFull
+	}
+	q.pending = append(q.pending, wh)
+	return nil
+}
+
+func (q *MemoryWebhookQueue) Pop() (*Webhook, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.pending) == 0 {
+		return nil, ErrEmptyQueue
+	}
+	wh := q.pending[0]
+	q.pending[0] = nil
+	q.pending = q.pending[1:]
+	return wh, nil
+}
+
+func (q *MemoryWebhookQueue) Ack(*Webhook) error { return nil }
+
+// Len is the quantity of notifications pending to be popped
+func (q *MemoryWebhookQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.pending)
+}
+
+// FileWebhookQueue is a durable queue which logs every push and ack onto its file.
+// Each push is identified by its sequence on the log, so acking a re-sent notification doesn't ack the
+// original one (which shares its key). On open, the log is replayed (and compacted), so the notifications
+// which weren't acked are pending again
+type FileWebhookQueue struct {
+	f        *os.File
+	filename string
+	seq      int64
+	pending  []*queuedWebhook
+	inFlight map[*Webhook]int64
+	// acked is the quantity of acks logged since the last compaction, which is performed
+	// once it reaches compactAcks, so the log doesn't grow forever on long-running processes
+	acked       int
+	compactAcks int
+	lock        sync.Mutex
+}
+
+// webhookQueueCompactAcks is the quantity of acks after which a FileWebhookQueue compacts its log
+const webhookQueueCompactAcks = 1000
+
+type queuedWebhook struct {
+	seq int64
+	wh  *Webhook
+}
+
+type webhookQueueRecord struct {
+	Op      string   `json:"op"`
+	Seq     int64    `json:"seq"`
+	Webhook *Webhook `json:"webhook,omitempty"`
+}
+
+const (
+	queueOpPush = "push"
+	queueOpAck  = "ack"
+)
+
+func NewFileWebhookQueue(filename string) (*FileWebhookQueue, error) {
+	pending, err := replayWebhookQueue(filename)
+	if err != nil {
+		return nil, err
+	}
+	q := &FileWebhookQueue{filename: filename, inFlight: make(map[*Webhook]int64), compactAcks: webhookQueueCompactAcks}
+	for _, wh := range pending {
+		q.seq++
+		q.pending = append(q.pending, &queuedWebhook{seq: q.seq, wh: wh})
+	}
+	err = q.compact()
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func replayWebhookQueue(filename string) ([]*Webhook, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var order []int64
+	pending := make(map[int64]*Webhook)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		rec := &webhookQueueRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			continue // A line can be partially written in case of crashing
+		}
+		switch rec.Op {
+		case queueOpPush:
+			if _, ok := pending[rec.Seq]; !ok {
+				order = append(order, rec.Seq)
+			}
+			pending[rec.Seq] = rec.Webhook
+		case queueOpAck:
+			delete(pending, rec.Seq)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	var whs []*Webhook
+	for _, seq := range order {
+		if wh, ok := pending[seq]; ok && wh != nil {
+			whs = append(whs, wh)
+			delete(pending, seq)
+		}
+	}
+	return whs, nil
+}
+
+// compact rewrites the log with only the notifications which weren't acked (the in-flight ones first),
+// keeping their sequences so the in-flight ones can still be acked
+func (q *FileWebhookQueue) compact() error {
+	tmp := q.filename + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	var unacked []*queuedWebhook
+	for wh, seq := range q.inFlight {
+		unacked = append(unacked, &queuedWebhook{seq: seq, wh: wh})
+	}
+	sort.Slice(unacked, func(i, j int) bool { return unacked[i].seq < unacked[j].seq })
+	unacked = append(unacked, q.pending...)
+	enc := json.NewEncoder(f)
+	for _, queued := range unacked {
+		err = enc.Encode(&webhookQueueRecord{Op: queueOpPush, Seq: queued.seq, Webhook: queued.wh})
+		if err != nil {
+			f.Close()
+			return err
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, q.filename)
+	if err != nil {
+		return err
+	}
+	if q.f != nil {
+		q.f.Close()
+	}
+	q.f, err = os.OpenFile(q.filename, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	q.acked = 0
+	return nil
+}
+
+func (q *FileWebhookQueue) log(rec *webhookQueueRecord) error {
+	content, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = q.f.Write(append(content, '\n'))
+	if err != nil {
+		return err
+	}
+	return q.f.Sync()
+}
+
+func (q *FileWebhookQueue) Push(wh *Webhook) error {
+	if wh == nil {
+		return ErrNilWebhook
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	err := q.log(&webhookQueueRecord{Op: queueOpPush, Seq: q.seq + 1, Webhook: wh})
+	if err != nil {
+		return err
+	}
+	q.seq++
+	pushed := *wh // Each push is popped as its own webhook, even if the same one is pushed twice
+	q.pending = append(q.pending, &queuedWebhook{seq: q.seq, wh: &pushed})
+	return nil
+}
+
+func (q *FileWebhookQueue) Pop() (*Webhook, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.pending) == 0 {
+		return nil, ErrEmptyQueue
+	}
+	queued := q.pending[0]
+	q.pending[0] = nil
+	q.pending = q.pending[1:]
+	q.inFlight[queued.wh] = queued.seq
+	return queued.wh, nil
+}
+
+func (q *FileWebhookQueue) Ack(wh *Webhook) error {
+	if wh == nil {
+		return ErrNilWebhook
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	seq, ok := q.inFlight[wh]
+	if !ok {
+		return nil // Never popped from this queue or already acked
+	}
+	err := q.log(&webhookQueueRecord{Op: queueOpAck, Seq: seq})
+	if err != nil {
+		return err
+	}
+	delete(q.inFlight, wh)
+	q.acked++
+	if q.acked >= q.compactAcks {
+		return q.compact()
+	}
+	return nil
+}
+
+// Len is the quantity of notifications pending to be popped
+func (q *FileWebhookQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.pending)
+}
+
+func (q *FileWebhookQueue) Close() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.f.Close()
+}
+
+// MemoryLedger keeps the processed keys up to its TTL (forever if ttl <= 0)
+type MemoryLedger struct {
+	ttl  time.Duration
+	keys map[string]*list.Element
+	// marks is sorted from the oldest to the newest mark, so the expired ones are evicted from its front
+	marks  *list.List
+	pushes map[string]*StockPush
+	lock   sync.Mutex
+}
+
+type ledgerMark struct {
+	key    string
+	marked time.Time
+}
+
+func NewMemoryLedger(ttl time.Duration) *MemoryLedger {
+	return &MemoryLedger{ttl: ttl, keys: make(map[string]*list.Element), marks: list.New(), pushes: make(map[string]*StockPush)}
+}
+
+func (l *MemoryLedger) Seen(key string) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.evict(time.Now())
+	_, ok := l.keys[key]
+	return ok, nil
+}
+
+func (l *MemoryLedger) Mark(key string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	l.evict(now)
+	if elem, ok := l.keys[key]; ok {
+		elem.Value.(*ledgerMark).marked = now
+		l.marks.MoveToBack(elem)
+		return nil
+	}
+	l.keys[key] = l.marks.PushBack(&ledgerMark{key: key, marked: now})
+	return nil
+}
+
+func (l *MemoryLedger) Claim(key string) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	l.evict(now)
+	if _, ok := l.keys[key]; ok {
+		return false, nil
+	}
+	l.keys[key] = l.marks.PushBack(&ledgerMark{key: key, marked: now})
+	return true, nil
+}
+
+func (l *MemoryLedger) Release(key string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if elem, ok := l.keys[key]; ok {
+		l.marks.Remove(elem)
+		delete(l.keys, key)
+	}
+	delete(l.pushes, key)
+	return nil
+}
+
+func (l *MemoryLedger) ClaimPush(key string, push *StockPush) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	l.evict(now)
+	if _, ok := l.keys[key]; ok {
+		return false, nil
+	}
+	l.keys[key] = l.marks.PushBack(&ledgerMark{key: key, marked: now})
+	l.pushes[key] = push
+	return true, nil
+}
+
+func (l *MemoryLedger) Pushes() (map[string]*StockPush, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	pushes := make(map[string]*StockPush, len(l.pushes))
+	for key, push := range l.pushes {
+		pushes[key] = push
+	}
+	return pushes, nil
+}
+
+func (l *MemoryLedger) Confirm(key string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.pushes, key)
+	return nil
+}
+
+func (l *MemoryLedger) evict(now time.Time) {
+	if l.ttl <= 0 {
+		return
+	}
+	for elem := l.marks.Front(); elem != nil; elem = l.marks.Front() {
+		mark := elem.Value.(*ledgerMark)
+		if now.Sub(mark.marked) <= l.ttl {
+			return
+		}
+		l.marks.Remove(elem)
+		delete(l.keys, mark.key)
+	}
+}
+
+const (
+	// ledgerReleasePrefix precedes the released keys on the file of a FileLedger
+	ledgerReleasePrefix = "-"
+	// ledgerPushPrefix precedes the keys claimed by a push, followed by a tab and the push as JSON
+	ledgerPushPrefix = "?"
+	// ledgerConfirmPrefix precedes the keys whose push was confirmed
+	ledgerConfirmPrefix = "+"
+)
+
+// FileLedger is a durable ledger which appends each marked key as a line of its file.
+// Released keys are appended prefixed by ledgerReleasePrefix, as the stock pushes by ledgerPushPrefix
+// (and their confirmations by ledgerConfirmPrefix)
+type FileLedger struct {
+	f      *os.File
+	keys   map[string]bool
+	pushes map[string]*StockPush
+	lock   sync.Mutex
+}
+
+func NewFileLedger(filename string) (*FileLedger, error) {
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool)
+	pushes := make(map[string]*StockPush)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, ledgerReleasePrefix):
+			key := strings.TrimPrefix(line, ledgerReleasePrefix)
+			delete(keys, key)
+			delete(pushes, key)
+		case strings.HasPrefix(line, ledgerPushPrefix):
+			fields := strings.SplitN(strings.TrimPrefix(line, ledgerPushPrefix), "\t", 2)
+			push := &StockPush{}
+			if len(fields) != 2 || json.Unmarshal([]byte(fields[1]), push) != nil {
+				continue // Skips a line truncated by a crash, which is never followed by its push
+			}
+			keys[fields[0]] = true
+			pushes[fields[0]] = push
+		case strings.HasPrefix(line, ledgerConfirmPrefix):
+			delete(pushes, strings.TrimPrefix(line, ledgerConfirmPrefix))
+		default:
+			keys[line] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &FileLedger{f: f, keys: keys, pushes: pushes}, nil
+}
+
+func (l *FileLedger) Seen(key string) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.keys[key], nil
+}
+
+func (l *FileLedger) Mark(key string) error {
+	_, err := l.Claim(key)
+	return err
+}
+
+func (l *FileLedger) Claim(key string) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.keys[key] {
+		return false, nil
+	}
+	err := l.append(key)
+	if err != nil {
+		return false, err
+	}
+	l.keys[key] = true
+	return true, nil
+}
+
+func (l *FileLedger) Release(key string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if !l.keys[key] {
+		return nil
+	}
+	err := l.append(ledgerReleasePrefix + key)
+	if err != nil {
+		return err
+	}
+	delete(l.keys, key)
+	delete(l.pushes, key)
+	return nil
+}
+
+func (l *FileLedger) ClaimPush(key string, push *StockPush) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.keys[key] {
+		return false, nil
+	}
+	encoded, err := json.Marshal(push)
+	if err != nil {
+		return false, err
+	}
+	// A single line both claims the key and keeps its push, so a crash can't leave it claimed without it
+	err = l.append(ledgerPushPrefix + key + "\t" + string(encoded))
+	if err != nil {
+		return false, err
+	}
+	l.keys[key] = true
+	l.pushes[key] = push
+	return true, nil
+}
+
+func (l *FileLedger) Pushes() (map[string]*StockPush, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	pushes := make(map[string]*StockPush, len(l.pushes))
+	for key, push := range l.pushes {
+		pushes[key] = push
+	}
+	return pushes, nil
+}
+
+func (l *FileLedger) Confirm(key string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.pushes[key]; !ok {
+		return nil
+	}
+	err := l.append(ledgerConfirmPrefix + key)
+	if err != nil {
+		return err
+	}
+	delete(l.pushes, key)
+	return nil
+}
+
+func (l *FileLedger) append(line string) error {
+	_, err := l.f.WriteString(line + "\n")
+	if err != nil {
+		return err
+	}
+	return l.f.Sync()
+}
+
+func (l *FileLedger) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.f.Close()
+}
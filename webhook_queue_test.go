@@ -0,0 +1,348 @@
+package meli
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFileWebhookQueue(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "meli")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "queue.jsonl")
+
+	whs := []*Webhook{
+		{Resource: "/items/MLA1", Topic: TopicItems, Sent: time.Unix(1, 0).UTC()},
+		{Resource: "/items/MLA2", Topic: TopicItems, Sent: time.Unix(2, 0).UTC()},
+		{Resource: "/items/MLA3", Topic: TopicItems, Sent: time.Unix(3, 0).UTC()},
+	}
+	q, err := NewFileWebhookQueue(filename)
+	if err != nil {
+		t.Fatalf("NewFileWebhookQueue() error = %v", err)
+	}
+	for _, wh := range whs {
+		if err := q.Push(wh); err != nil {
+			t.Fatalf("FileWebhookQueue.Push() error = %v", err)
+		}
+	}
+	first, err := q.Pop()
+	if err != nil {
+		t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+	}
+	if err := q.Ack(first); err != nil {
+		t.Fatalf("FileWebhookQueue.Ack() error = %v", err)
+	}
+	if _, err := q.Pop(); err != nil { // Popped but never acked (e.g. crashed while processing)
+		t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("FileWebhookQueue.Close() error = %v", err)
+	}
+
+	reopened, err := NewFileWebhookQueue(filename)
+	if err != nil {
+		t.Fatalf("NewFileWebhookQueue() on reopen error = %v", err)
+	}
+	defer reopened.Close()
+	var got []*Webhook
+	for {
+		wh, err := reopened.Pop()
+		if err == ErrEmptyQueue {
+			break
+		}
+		if err != nil {
+			t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+		}
+		got = append(got, wh)
+	}
+	if diff := cmp.Diff(whs[1:], got); diff != "" {
+		t.Errorf("NewFileWebhookQueue() pending mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestFileWebhookQueue_Ack(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "meli")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "queue.jsonl")
+
+	wh := &Webhook{Resource: "/items/MLA1", Topic: TopicItems, Sent: time.Unix(1, 0).UTC()}
+	q, err := NewFileWebhookQueue(filename)
+	if err != nil {
+		t.Fatalf("NewFileWebhookQueue() error = %v", err)
+	}
+	for _, attempt := range []int{1, 2} { // The original and its re-sent attempt share the key
+		resent := *wh
+		resent.Attempts = attempt
+		if err := q.Push(&resent); err != nil {
+			t.Fatalf("FileWebhookQueue.Push() error = %v", err)
+		}
+	}
+	if _, err := q.Pop(); err != nil { // The original, in flight when crashing
+		t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+	}
+	dup, err := q.Pop()
+	if err != nil {
+		t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+	}
+	if err := q.Ack(dup); err != nil {
+		t.Fatalf("FileWebhookQueue.Ack() error = %v", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("FileWebhookQueue.Close() error = %v", err)
+	}
+
+	reopened, err := NewFileWebhookQueue(filename)
+	if err != nil {
+		t.Fatalf("NewFileWebhookQueue() on reopen error = %v", err)
+	}
+	defer reopened.Close()
+	if reopened.Len() != 1 {
+		t.Fatalf("FileWebhookQueue.Len() = %v, want %v", reopened.Len(), 1)
+	}
+	got, err := reopened.Pop()
+	if err != nil {
+		t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+	}
+	if got.Attempts != 1 {
+		t.Errorf("FileWebhookQueue.Ack() of the re-sent attempt ACKED the original one")
+	}
+}
+
+func TestFileWebhookQueue_compact(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "meli")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "queue.jsonl")
+
+	q, err := NewFileWebhookQueue(filename)
+	if err != nil {
+		t.Fatalf("NewFileWebhookQueue() error = %v", err)
+	}
+	q.compactAcks = 2
+	for i := 1; i <= 4; i++ {
+		if err := q.Push(&Webhook{Resource: fmt.Sprintf("/items/MLA%v", i), Topic: TopicItems}); err != nil {
+			t.Fatalf("FileWebhookQueue.Push() error = %v", err)
+		}
+	}
+	var popped []*Webhook
+	for i := 0; i < 3; i++ {
+		wh, err := q.Pop()
+		if err != nil {
+			t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+		}
+		popped = append(popped, wh)
+	}
+	for _, wh := range popped[:2] { // The second ack compacts the log
+		if err := q.Ack(wh); err != nil {
+			t.Fatalf("FileWebhookQueue.Ack() error = %v", err)
+		}
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("couldn't read the log: %v", err)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != 2 {
+		t.Errorf("FileWebhookQueue.Ack() left %v lines on the compacted log, want %v", lines, 2)
+	}
+	// The in-flight one is still acked after the compaction
+	if err := q.Ack(popped[2]); err != nil {
+		t.Fatalf("FileWebhookQueue.Ack() error = %v", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("FileWebhookQueue.Close() error = %v", err)
+	}
+
+	reopened, err := NewFileWebhookQueue(filename)
+	if err != nil {
+		t.Fatalf("NewFileWebhookQueue() on reopen error = %v", err)
+	}
+	defer reopened.Close()
+	got, err := reopened.Pop()
+	if err != nil {
+		t.Fatalf("FileWebhookQueue.Pop() error = %v", err)
+	}
+	if got.Resource != "/items/MLA4" || reopened.Len() != 0 {
+		t.Errorf("NewFileWebhookQueue() pending = %v (and %v more), want only /items/MLA4", got.Resource, reopened.Len())
+	}
+}
+
+func TestMemoryLedger_Mark(t *testing.T) {
+	t.Parallel()
+	l := NewMemoryLedger(time.Hour)
+	for _, key := range []string{"foo", "bar"} {
+		if err := l.Mark(key); err != nil {
+			t.Fatalf("MemoryLedger.Mark() error = %v", err)
+		}
+	}
+	// Backdates foo, so it's expired while bar isn't
+	l.keys["foo"].Value.(*ledgerMark).marked = time.Now().Add(-2 * time.Hour)
+	l.marks.MoveToFront(l.keys["foo"])
+	if err := l.Mark("baz"); err != nil {
+		t.Fatalf("MemoryLedger.Mark() error = %v", err)
+	}
+	if _, ok := l.keys["foo"]; ok {
+		t.Errorf("MemoryLedger.Mark() did NOT EVICT the expired key")
+	}
+	for _, key := range []string{"bar", "baz"} {
+		if seen, _ := l.Seen(key); !seen {
+			t.Errorf("MemoryLedger.Seen(%v) = %v, want %v", key, seen, true)
+		}
+	}
+	if l.marks.Len() != 2 {
+		t.Errorf("MemoryLedger marks = %v, want %v", l.marks.Len(), 2)
+	}
+}
+
+func TestWebhookHandler_Enqueue(t *testing.T) {
+	t.Parallel()
+	h := (&MeLi{}).NewWebhookHandlerWithOptions(&WebhookHandlerOptions{
+		Workers: 2, MaxRetries: 2, Backoff: time.Millisecond,
+	})
+	var lock sync.Mutex
+	var dispatched sync.WaitGroup // Done on the last expected call of each notification
+	dispatched.Add(3)
+	calls := make(map[string]int)
+	h.Handle(TopicItems, func(wh *Webhook) error {
+		lock.Lock()
+		defer lock.Unlock()
+		calls[wh.Resource]++
+		switch wh.Resource {
+		case "/items/MLA1":
+			dispatched.Done()
+		case "/items/MLA2":
+			if calls[wh.Resource] < 2 {
+				return errors.New("temporary")
+			}
+			dispatched.Done()
+		case "/items/MLA3":
+			if calls[wh.Resource] == 3 {
+				dispatched.Done()
+			}
+			return errFoo
+		}
+		return nil
+	})
+	var failed []string
+	h.OnError = func(wh *Webhook, err error) {
+		lock.Lock()
+		defer lock.Unlock()
+		failed = append(failed, wh.Resource)
+	}
+
+	sent := time.Unix(1, 0)
+	for _, wh := range []*Webhook{
+		{Resource: "/items/MLA1", Topic: TopicItems, Sent: sent},
+		{Resource: "/items/MLA2", Topic: TopicItems, Sent: sent},
+		{Resource: "/items/MLA3", Topic: TopicItems, Sent: sent},
+	} {
+		if err := h.Enqueue(wh); err != nil {
+			t.Fatalf("WebhookHandler.Enqueue() error = %v", err)
+		}
+	}
+	dispatched.Wait()
+	// Re-sent attempt of an already processed (or still being marked) notification
+	if err := h.Enqueue(&Webhook{Resource: "/items/MLA1", Topic: TopicItems, Sent: sent, Attempts: 2}); err != nil {
+		t.Fatalf("WebhookHandler.Enqueue() error = %v", err)
+	}
+	h.Close()
+
+	wantCalls := map[string]int{"/items/MLA1": 1, "/items/MLA2": 2, "/items/MLA3": 3}
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Errorf("WebhookHandler calls mismatch (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"/items/MLA3"}, failed); diff != "" {
+		t.Errorf("WebhookHandler.OnError mismatch (-want +got): %s", diff)
+	}
+	if err := h.Enqueue(&Webhook{Resource: "/items/MLA4", Topic: TopicItems}); err != ErrClosedWebhookHandler {
+		t.Errorf("WebhookHandler.Enqueue() after close error = %v, want %v", err, ErrClosedWebhookHandler)
+	}
+}
+
+func TestFileLedger_Claim(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "meli")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "ledger")
+
+	l, err := NewFileLedger(filename)
+	if err != nil {
+		t.Fatalf("NewFileLedger() error = %v", err)
+	}
+	for _, key := range []string{"foo", "bar"} {
+		if claimed, err := l.Claim(key); err != nil || !claimed {
+			t.Fatalf("FileLedger.Claim(%v) = %v, %v, want %v", key, claimed, err, true)
+		}
+	}
+	if claimed, _ := l.Claim("foo"); claimed {
+		t.Errorf("FileLedger.Claim() CLAIMED an already claimed key")
+	}
+	if err := l.Release("bar"); err != nil {
+		t.Fatalf("FileLedger.Release() error = %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("FileLedger.Close() error = %v", err)
+	}
+
+	reopened, err := NewFileLedger(filename)
+	if err != nil {
+		t.Fatalf("NewFileLedger() on reopen error = %v", err)
+	}
+	defer reopened.Close()
+	for key, want := range map[string]bool{"foo": true, "bar": false} {
+		if seen, _ := reopened.Seen(key); seen != want {
+			t.Errorf("FileLedger.Seen(%v) after reopen = %v, want %v", key, seen, want)
+		}
+	}
+}
+
+func TestWebhookHandler_Unbuffered(t *testing.T) {
+	t.Parallel()
+	h := (&MeLi{}).NewWebhookHandler(1, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	h.Handle(TopicItems, func(wh *Webhook) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	// Accepted once the worker is idle
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := h.Enqueue(&Webhook{Resource: "/items/MLA1", Topic: TopicItems})
+		if err == nil {
+			break
+		}
+		if err != ErrQueueFull || time.Now().After(deadline) {
+			t.Fatalf("WebhookHandler.Enqueue() error = %v, wantErr %v", err, nil)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	<-started
+	if err := h.Enqueue(&Webhook{Resource: "/items/MLA2", Topic: TopicItems}); err != ErrQueueFull {
+		t.Errorf("WebhookHandler.Enqueue() with the worker busy error = %v, wantErr %v", err, ErrQueueFull)
+	}
+	close(release)
+	h.Close()
+}
@@ -0,0 +1,187 @@
+package meli
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhookHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		creds      *creds
+		method     string
+		body       []byte
+		wantStatus int
+		wantWh     *Webhook
+		wantErr    error
+	}{
+		{
+			name:       "NOT a POST",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "body is NOT a WEBHOOK",
+			method:     http.MethodPost,
+			body:       []byte("foo"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "APPLICATION ID does NOT MATCH",
+			creds:      &creds{ApplicationId: "123"},
+			method:     http.MethodPost,
+			body:       []byte(`{"resource":"/items/MLA1","topic":"items","application_id":456}`),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "topic is NOT HANDLED",
+			creds:      &creds{ApplicationId: "123"},
+			method:     http.MethodPost,
+			body:       []byte(`{"resource":"/orders/1","topic":"orders_v2","application_id":123}`),
+			wantStatus: http.StatusOK,
+			wantErr:    ErrUnhandledTopic,
+		},
+		{
+			name:       "DISPATCHED CORRECTly",
+			creds:      &creds{ApplicationId: "123"},
+			method:     http.MethodPost,
+			body:       []byte(`{"resource":"/items/MLA1","topic":"items","application_id":123,"attempts":2}`),
+			wantStatus: http.StatusOK,
+			wantWh:     &Webhook{Resource: "/items/MLA1", Topic: "items", ApplicationId: 123, Attempts: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ml := &MeLi{creds: tt.creds}
+			h := ml.NewWebhookHandler(1, 1)
+			var lock sync.Mutex
+			var gotWh *Webhook
+			var gotErr error
+			h.Handle(TopicItems, func(wh *Webhook) error {
+				lock.Lock()
+				defer lock.Unlock()
+				gotWh = wh
+				return nil
+			})
+			h.OnError = func(wh *Webhook, err error) {
+				lock.Lock()
+				defer lock.Unlock()
+				gotErr = err
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, "/notifications", bytes.NewReader(tt.body)))
+			h.Close()
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("WebhookHandler.ServeHTTP() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if fmt.Sprintf("%v", gotErr) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("WebhookHandler.Dispatch() error = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if gotWh != nil {
+				if gotWh.Received.IsZero() {
+					t.Errorf("WebhookHandler.ServeHTTP() did NOT SET the received time")
+				}
+				gotWh.Received = tt.wantWh.Received
+			}
+			if diff := cmp.Diff(tt.wantWh, gotWh); diff != "" {
+				t.Errorf("WebhookHandler.ServeHTTP() dispatched mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_ParseResource(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		wh      *Webhook
+		want    *Resource
+		wantId  string
+		wantErr error
+	}{
+		{
+			name:   "item",
+			wh:     &Webhook{Resource: "/items/MLA123", Topic: TopicItems},
+			want:   &Resource{Kind: ResourceItems, Id: "MLA123"},
+			wantId: "MLA123",
+		},
+		{
+			name:   "item variation",
+			wh:     &Webhook{Resource: "/items/MLA123/variations/456", Topic: TopicItems},
+			want:   &Resource{Kind: ResourceItems, Id: "MLA123", Sub: "variations", SubId: "456"},
+			wantId: "MLA123",
+		},
+		{
+			name:   "order",
+			wh:     &Webhook{Resource: "/orders/2000001", Topic: TopicOrders},
+			want:   &Resource{Kind: ResourceOrders, Id: "2000001"},
+			wantId: "2000001",
+		},
+		{
+			name:   "shipment",
+			wh:     &Webhook{Resource: "/shipments/4000001", Topic: TopicShipments},
+			want:   &Resource{Kind: ResourceShipments, Id: "4000001"},
+			wantId: "4000001",
+		},
+		{
+			name:   "bare message id",
+			wh:     &Webhook{Resource: "abcdef", Topic: TopicMessages},
+			want:   &Resource{Kind: ResourceMessages, Id: "abcdef"},
+			wantId: "abcdef",
+		},
+		{
+			name:   "post-purchase claim",
+			wh:     &Webhook{Resource: "/post-purchase/v1/claims/5000001", Topic: TopicClaims},
+			want:   &Resource{Kind: ResourceClaims, Id: "5000001"},
+			wantId: "5000001",
+		},
+		{
+			name:   "bare claim id",
+			wh:     &Webhook{Resource: "5000001", Topic: TopicClaims},
+			want:   &Resource{Kind: ResourceClaims, Id: "5000001"},
+			wantId: "5000001",
+		},
+		{
+			name:    "EMPTY resource",
+			wh:      &Webhook{Topic: TopicItems},
+			wantErr: ErrInvalidResource,
+		},
+		{
+			name:    "resource WITHOUT ID",
+			wh:      &Webhook{Resource: "/items/", Topic: TopicItems},
+			wantErr: ErrInvalidResource,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.wh.ParseResource()
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tt.wantErr) {
+				t.Errorf("Webhook.ParseResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Webhook.ParseResource() mismatch (-want +got): %s", diff)
+			}
+			if tt.wh.ResourceID() != tt.wantId {
+				t.Errorf("Webhook.ResourceID() = %v, want %v", tt.wh.ResourceID(), tt.wantId)
+			}
+			// The paths are formatted back as they were sent
+			if got != nil && strings.HasPrefix(tt.wh.Resource, "/") && got.String() != tt.wh.Resource {
+				t.Errorf("Resource.String() = %v, want %v", got.String(), tt.wh.Resource)
+			}
+		})
+	}
+}